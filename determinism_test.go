@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+// populationTrajectory runs a small headless simulation with the given
+// thread count and returns the fish/shark population after each chronon.
+func populationTrajectory(t *testing.T, threads int) []int {
+	t.Helper()
+
+	*seedFlag = 42
+	*wwidth, *wheight = 40, 40
+	*nFish, *nSharks = 50, 20
+	*fBreed, *sBreed, *starve = 10, 12, 10
+	*nThreads = threads
+
+	resolveSeed()
+	world, nextWorld = initWator()
+
+	const chronons = 25
+	trajectory := make([]int, chronons)
+	for i := 0; i < chronons; i++ {
+		Chronon(i)
+		trajectory[i] = countPopulation()
+	}
+	return trajectory
+}
+
+// countPopulation returns the number of live creatures currently in world.
+func countPopulation() int {
+	n := 0
+	for x := range world {
+		for y := range world[x] {
+			if world[x][y] != nil {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// TestDeterministicReplayAcrossThreadCounts asserts that a fixed -seed
+// produces the exact same population trajectory whether the simulation runs
+// with one worker goroutine or several, which is the whole point of
+// deriving each block's RNG stream from (seed, chronon, block) instead of
+// reseeding from crypto/rand per goroutine per chronon.
+func TestDeterministicReplayAcrossThreadCounts(t *testing.T) {
+	single := populationTrajectory(t, 1)
+	multi := populationTrajectory(t, 4)
+
+	if len(single) != len(multi) {
+		t.Fatalf("trajectory length mismatch: %d vs %d", len(single), len(multi))
+	}
+	for i := range single {
+		if single[i] != multi[i] {
+			t.Fatalf("population diverged at chronon %d: threads=1 got %d, threads=4 got %d", i, single[i], multi[i])
+		}
+	}
+}
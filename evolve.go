@@ -0,0 +1,204 @@
+// This file adds -evolve mode: instead of the hard-coded "hunt then wander"
+// shark rules, each shark carries its own tiny neural network (see net.go)
+// and moves wherever that network's argmax output points. Reproduction
+// passes the parent's weights to the child with Gaussian mutation, so
+// policies drift across generations and -brainstats lets you watch that
+// drift converge.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+)
+
+// Evolution configuration flags.
+var (
+	// evolve swaps the classical shark rules for the neuroevolution policy below.
+	evolve = flag.Bool("evolve", false, "Replace hard-coded shark movement with an evolving neural network policy.")
+
+	// mutationRate is the per-weight probability of mutation on reproduction.
+	mutationRate = flag.Float64("mutation-rate", 0.02, "Per-weight probability of mutation when a shark reproduces in -evolve mode.")
+
+	// mutationStddev is the standard deviation of the Gaussian noise applied to a mutated weight.
+	mutationStddev = flag.Float64("mutation-stddev", 1.0, "Standard deviation of mutation noise in -evolve mode.")
+
+	// brainstatsEvery, if nonzero, logs weight mean/variance and average shark age every N chronons.
+	brainstatsEvery = flag.Int("brainstats", 0, "Log brain weight mean/variance and average shark age every N chronons (0 disables).")
+)
+
+// applyEvolveMode installs the SHARK behavior once flags are parsed:
+// evolvingSharkSpecies when -evolve is set, otherwise a fresh sharkSpecies
+// so that -scent-temperature takes effect. It must run after flag.Parse,
+// since the package-level Species registry is otherwise populated by init()
+// before flags are available.
+func applyEvolveMode() {
+	if *evolve {
+		RegisterSpecies(SHARK, &evolvingSharkSpecies{})
+		return
+	}
+	RegisterSpecies(SHARK, newSharkSpecies())
+}
+
+// evolvingSharkSpecies replaces the classical hunt/wander rules with a
+// per-shark feed-forward network: it still eats an adjacent fish outright
+// (that part of the rules isn't learned), but when no fish is adjacent it
+// picks its move from the network's argmax output over the 4 directions
+// instead of a scent gradient or uniform random choice.
+//
+// Fish do not carry an evasion net in this implementation; the `weights`
+// field exists generically on creature so that a future evasion policy can
+// reuse the same storage and net.go helpers without another struct change.
+type evolvingSharkSpecies struct{}
+
+func (s *evolvingSharkSpecies) Color() color.RGBA { return sharkcolor }
+
+func (s *evolvingSharkSpecies) Step(ctx *StepCtx, cr *creature) bool {
+	cr.health--
+	if cr.health <= 0 {
+		s.OnDeath(cr)
+		return false
+	}
+
+	if cr.weights == nil {
+		cr.weights = newBrainWeights(ctx.Rand)
+	}
+
+	// Hunting still short-circuits the learned policy: an adjacent fish is
+	// always eaten, exactly as the classical shark would.
+	for i := 0; i < 4; i++ {
+		nx, ny := pickDirection(ctx, i)
+		if world[nx][ny] == nil || world[nx][ny].species != FISH {
+			continue
+		}
+		cr.health = *starve
+		if !cas(nx, ny, cr) {
+			continue
+		}
+		recordDeath(FISH)
+		if baby := s.reproduce(ctx, cr); baby != nil {
+			cas(ctx.X, ctx.Y, baby)
+		}
+		return true
+	}
+
+	if s.stepByPolicy(ctx, cr) {
+		return true
+	}
+
+	cas(ctx.X, ctx.Y, cr)
+	return true
+}
+
+// stepByPolicy evaluates the shark's network on its 4 neighbors and moves
+// to the argmax direction if that cell is empty, falling back to the next
+// best-scoring empty direction otherwise.
+func (s *evolvingSharkSpecies) stepByPolicy(ctx *StepCtx, cr *creature) bool {
+	north, south, east, west := adjacent(ctx.X, ctx.Y)
+	neighbors := [4]coordinate{north, south, east, west}
+
+	var inputs [brainInputs]float32
+	for i, n := range neighbors {
+		if occupant := world[n.x][n.y]; occupant != nil {
+			inputs[i*2] = float32(occupant.species)
+			inputs[i*2+1] = float32(occupant.age)
+		} else {
+			inputs[i*2] = -1
+			inputs[i*2+1] = 0
+		}
+	}
+
+	logits := asBrain(cr.weights).forward(inputs)
+
+	// Try directions in order of preference (highest logit first); pick the
+	// first one that's empty and claimable.
+	order := [4]int{0, 1, 2, 3}
+	for i := 0; i < 4; i++ {
+		best := i
+		for j := i + 1; j < 4; j++ {
+			if logits[order[j]] > logits[order[best]] {
+				best = j
+			}
+		}
+		order[i], order[best] = order[best], order[i]
+
+		c := neighbors[order[i]]
+		if world[c.x][c.y] != nil {
+			continue
+		}
+		if !cas(c.x, c.y, cr) {
+			continue
+		}
+		if baby := s.reproduce(ctx, cr); baby != nil {
+			cas(ctx.X, ctx.Y, baby)
+		}
+		return true
+	}
+	return false
+}
+
+// reproduce breeds cr if it has reached *sBreed age, returning a child
+// whose weights are the parent's, mutated via ctx.Rand. It takes ctx
+// (rather than satisfying the bare Species.Reproduce signature used by
+// fishSpecies/sharkSpecies) because mutation needs a random stream, and
+// every call site here already has one.
+func (s *evolvingSharkSpecies) reproduce(ctx *StepCtx, cr *creature) *creature {
+	if cr.age == 0 || cr.age%*sBreed != 0 {
+		return nil
+	}
+	childEnergy := cr.health / 2
+	cr.health -= childEnergy
+
+	child := &creature{age: 0, health: childEnergy, species: SHARK, asset: sharkcolor, chronon: cr.chronon}
+	child.weights = mutate(cr.weights, *mutationRate, *mutationStddev, ctx.Rand)
+	recordBirth(SHARK)
+	return child
+}
+
+// Reproduce satisfies the Species interface for parity with the other
+// registrations, but evolvingSharkSpecies never calls it directly since
+// mutation needs ctx.Rand; see reproduce above.
+func (s *evolvingSharkSpecies) Reproduce(cr *creature) *creature { return nil }
+
+func (s *evolvingSharkSpecies) OnDeath(cr *creature) { recordDeath(SHARK) }
+
+// logBrainStats prints the mean/variance of every living shark's weights
+// and their average age, used as a rough proxy for lifespan under the
+// current -evolve policy. Chronon calls it every *brainstatsEvery ticks.
+func logBrainStats(c int) {
+	if *brainstatsEvery == 0 || c%*brainstatsEvery != 0 {
+		return
+	}
+
+	var sum, sumSq float64
+	var weightCount int
+	var totalAge, sharkCount int
+
+	for x := range world {
+		for y := range world[x] {
+			cr := world[x][y]
+			if cr == nil || cr.species != SHARK {
+				continue
+			}
+			sharkCount++
+			totalAge += cr.age
+			for _, w := range cr.weights {
+				sum += float64(w)
+				sumSq += float64(w) * float64(w)
+				weightCount++
+			}
+		}
+	}
+
+	if sharkCount == 0 || weightCount == 0 {
+		fmt.Printf("[brainstats] chronon=%d no sharks alive\n", c)
+		return
+	}
+
+	mean := sum / float64(weightCount)
+	variance := sumSq/float64(weightCount) - mean*mean
+	avgAge := float64(totalAge) / float64(sharkCount)
+
+	fmt.Printf("[brainstats] chronon=%d sharks=%d weightMean=%.4f weightVar=%.4f avgAge=%.2f\n",
+		c, sharkCount, mean, variance, avgAge)
+}
@@ -15,19 +15,14 @@
 package main
 
 import (
-	crand "crypto/rand"
-	"encoding/binary"
 	"flag"
 	"fmt"
 	"image/color"
 	"log"
-	"math/rand"
 	"runtime"
 	"strconv"
 	"sync"
-	"sync/atomic"
 	"time"
-	"unsafe"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -95,6 +90,14 @@ var (
 	// nextWorld is the future state of the simulation grid.
 	// It is the write buffer where updates are stored during a cycle.
 	nextWorld [][]*creature
+
+	// worldMu guards the world slice header against the -metrics HTTP
+	// handlers (see metrics.go), which read it from their own goroutine
+	// concurrently with Chronon reassigning it (world, nextWorld =
+	// nextWorld, world) and mutating nextWorld's cells every tick.
+	// updateSlice's own workers don't need it: they only ever touch
+	// nextWorld through the cas helper, never world's header.
+	worldMu sync.RWMutex
 )
 
 // Species constants.
@@ -118,54 +121,129 @@ type creature struct {
 	species int        // The type of creature: FISH or SHARK.
 	asset   color.RGBA // The color used to render this creature.
 	chronon int        // The last tick index this creature was processed (prevents double updates).
+	weights []float32  // Flat neural-network weights (see net.go); only populated in -evolve mode.
+}
+
+// numChrononBlocks is the largest number of horizontal blocks the world is
+// partitioned into for each chronon (Chronon uses fewer on a short world).
+// It is independent of *nThreads so that
+// the same (seed, chronon, block) always gets the same deterministic RNG
+// stream (see determinism.go) no matter how many worker goroutines are
+// actually running — only how the blocks are divided among workers changes
+// with -threads, never the blocks themselves or their seeds.
+const numChrononBlocks = 64
+
+// blockBounds returns the [startY, endY) row range owned by block out of n
+// total blocks spanning the world height.
+func blockBounds(block, n int) (startY, endY int) {
+	rowsPerBlock := *wheight / n
+	startY = block * rowsPerBlock
+	endY = startY + rowsPerBlock
+	if block == n-1 {
+		endY = *wheight
+	}
+	return startY, endY
 }
 
 // Chronon advances the simulation by a single unit of time.
 //
 // It implements a concurrent "fork-join" pattern:
-// 1. Partitioning: The world height is divided into horizontal strips based on *nThreads.
-// 2. Processing: Goroutines are spawned to process each strip (updateSlice).
-// 3. Synchronization: The main thread waits for all goroutines to finish via sync.WaitGroup.
+// 1. Partitioning: The world height is divided into an even number of blocks (at most numChrononBlocks, each at least 2 rows), independent of *nThreads.
+// 2. Processing: In two phases, even-numbered blocks and then odd-numbered ones, a pool of *nThreads workers pulls that phase's blocks off a shared channel and runs updateSlice on each.
+// 3. Synchronization: The main thread waits for all workers to finish each phase via sync.WaitGroup.
 // 4. Swapping: The 'nextWorld' buffer becomes the 'world' buffer for the next frame.
 //
+// Fixing the block partition independent of *nThreads is what makes a run
+// reproducible across different -threads values for the same -seed: which
+// worker happens to process a block can vary, but the block's own RNG
+// stream (derived from seed, chronon, and block index) never does, and
+// blocks that run at the same time never compete for the same nextWorld
+// cell, so no CAS outcome depends on goroutine timing.
+//
+// The scent field (see scent.go) is diffused across the same block
+// partitioning, as its own phase after every worker has finished moving
+// creatures, and double-buffer-swapped alongside world/nextWorld. During
+// the movement phases sharks read that field as diffused at the end of the
+// previous chronon plus the deposits of fish already processed this chronon.
+//
+// In replay mode (activeReplayer != nil, see scenario.go) Chronon skips all
+// of the above and simply serves the next pre-recorded frame as world,
+// leaving nextWorld untouched; there is nothing left to simulate.
+//
 // c represents the current tick index.
 func Chronon(c int) {
-	var wg sync.WaitGroup
+	if activeReplayer != nil {
+		frame, ok := activeReplayer.next()
+		if !ok {
+			log.Fatalf("replay: log ended at chronon %d", c)
+		}
+		world = frame
+		return
+	}
 
-	numGoroutines := *nThreads
+	var wg sync.WaitGroup
 
-	// Safety check for invalid thread counts.
-	if numGoroutines <= 0 {
-		numGoroutines = 1
+	// Keep the block count even and every block at least 2 rows tall, so
+	// two blocks of the same parity never write the same nextWorld row.
+	numBlocks := numChrononBlocks
+	if numBlocks > *wheight/2 {
+		numBlocks = *wheight / 2
 	}
-
-	// Ensure we don't have more threads than rows.
-	if numGoroutines > *wheight {
-		numGoroutines = *wheight
+	if numBlocks > 1 && numBlocks%2 == 1 {
+		numBlocks--
+	}
+	if numBlocks < 1 {
+		numBlocks = 1
 	}
 
-	rowsPerGoroutine := *wheight / numGoroutines
-
-	// Launch worker threads to update slices of the grid.
-	for i := 0; i < numGoroutines; i++ {
-		startY := i * rowsPerGoroutine
-		endY := startY + rowsPerGoroutine
+	numWorkers := *nThreads
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+	if numWorkers > numBlocks {
+		numWorkers = numBlocks
+	}
 
-		// Ensure the last routine covers any remaining rows due to integer division.
-		if i == numGoroutines-1 {
-			endY = *wheight
+	// Even-numbered blocks first, then odd-numbered ones, each phase behind
+	// its own barrier.
+	for phase := 0; phase < 2; phase++ {
+		blocks := make(chan int, numBlocks)
+		for b := phase; b < numBlocks; b += 2 {
+			blocks <- b
+		}
+		close(blocks)
+
+		for i := 0; i < numWorkers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for block := range blocks {
+					startY, endY := blockBounds(block, numBlocks)
+					updateSlice(c, block, startY, endY)
+				}
+			}()
 		}
 
-		wg.Add(1)
-		go updateSlice(c, startY, endY, &wg)
+		// Wait for all slice updates of this phase to complete.
+		wg.Wait()
 	}
 
-	// Wait for all slice updates to complete.
-	wg.Wait()
+	// Scent diffusion reads neighbor cells across block boundaries, so it
+	// must run as its own phase behind the movement-phase barrier above,
+	// not interleaved per-block on the same workers (see diffuseScent).
+	if scentField != nil {
+		diffuseScent(numBlocks, numWorkers)
+	}
 
-	// Swap double buffers.
-	// The fully populated nextWorld becomes the read-only world for the next frame.
+	// Swap double buffers. Locked against the -metrics HTTP handlers (see
+	// metrics.go), which read the world slice header from their own
+	// goroutine and would otherwise risk a torn read of this reassignment.
+	worldMu.Lock()
 	world, nextWorld = nextWorld, world
+	worldMu.Unlock()
+	if scentField != nil {
+		scentField, nextScentField = nextScentField, scentField
+	}
 
 	// Reset the new write buffer (nextWorld) to nil pointers.
 	for i := range nextWorld {
@@ -173,34 +251,34 @@ func Chronon(c int) {
 			nextWorld[i][j] = nil
 		}
 	}
+
+	if *evolve {
+		logBrainStats(c)
+	}
+	if *metricsAddr != "" {
+		recordChrononStats(c)
+	}
 }
 
 // updateSlice processes the logic for a horizontal strip of the world.
 //
-// It iterates through the assigned rows (startY to endY) and applies the Wa-Tor rules:
-//   - Fish: Move randomly, breed if age > fBreed.
-//   - Shark: Lose energy, hunt fish, move randomly if no food, breed if age > sBreed, die if health <= 0.
+// It iterates through the assigned rows (startY to endY) and delegates each
+// live creature to the Species registered for its species id (see
+// species.go), so the FISH/SHARK rules themselves live outside this
+// function and new species can be added without editing it.
+//
+// block identifies this strip's position in the fixed numChrononBlocks
+// partition (see Chronon); it is combined with the root seed and the
+// current chronon to derive this call's RNG stream deterministically (see
+// determinism.go), independent of which goroutine or how many -threads
+// happened to execute it.
 //
 // Concurrency Safety:
 // Since creatures moving near the boundary of a slice might attempt to write to the same
-// cell in 'nextWorld' as a neighbor thread, this function uses atomic.CompareAndSwapPointer
-// to safely claim a target cell.
-func updateSlice(c, startY, endY int, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	// Initialize a thread-local random number generator.
-	// Using the global rand.Intn would require a mutex lock, slowing down concurrent execution.
-	var seed int64
-	var b [8]byte
-	_, err := crand.Read(b[:])
-	if err != nil {
-		seed = time.Now().UnixNano() + int64(startY)
-	} else {
-		seed = int64(binary.LittleEndian.Uint64(b[:]))
-	}
-	r := rand.New(rand.NewSource(seed))
-
-	var newX, newY int
+// cell in 'nextWorld' as a neighbor thread, Species implementations use the shared cas
+// helper (atomic.CompareAndSwapPointer) to safely claim a target cell.
+func updateSlice(c, block, startY, endY int) {
+	ctx := &StepCtx{Chronon: c, Rand: blockRand(c, block)}
 
 	for y := startY; y < endY; y++ {
 		for x := 0; x < *wwidth; x++ {
@@ -216,136 +294,12 @@ func updateSlice(c, startY, endY int, wg *sync.WaitGroup) {
 			cr.age++
 			cr.chronon = c
 
-			moved := false
-
-			switch cr.species {
-			case FISH:
-				// --- FISH BEHAVIOR ---
-				// Try to move to a random adjacent empty spot.
-				for i := 0; i < 4; i++ {
-					north, south, east, west := adjacent(x, y)
-					d := r.Intn(4)
-					// Randomize direction check order
-					switch (d + i) % 4 {
-					case NORTH:
-						newX, newY = north.x, north.y
-					case SOUTH:
-						newX, newY = south.x, south.y
-					case EAST:
-						newX, newY = east.x, east.y
-					case WEST:
-						newX, newY = west.x, west.y
-					}
-
-					// Check if target spot in the current world is empty.
-					// Note: Wa-Tor usually checks the *current* world for emptiness.
-					if world[newX][newY] == nil {
-						// Atomic CAS: Try to write the fish to the nextWorld slot.
-						// If nextWorld[newX][newY] is not nil, another thread (or this one) already filled it.
-						if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[newX][newY])), nil, unsafe.Pointer(&cr)) {
-							moved = true
-							// Breeding logic: Leave a new baby fish in the old spot.
-							if cr.age > 0 && cr.age%*fBreed == 0 {
-								babyFish := &creature{age: 0, species: FISH, asset: fishcolor, chronon: c}
-								// We don't strictly need CAS here if we assume only one thing leaves a square,
-								// but it's safer for correctness.
-								atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(babyFish))
-							}
-							break
-						}
-					}
-				}
-
-				// If the fish couldn't move, it stays in the same spot.
-				if !moved {
-					atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(&cr))
-				}
-
-			case SHARK:
-				// --- SHARK BEHAVIOR ---
-				// 1. Metabolism: Lose energy.
-				cr.health--
-				if cr.health <= 0 {
-					// Shark dies (we simply do not add it to nextWorld).
-					continue
-				}
-
-				// 2. Hunting: Try to find a fish in adjacent cells.
-				for i := 0; i < 4; i++ {
-					north, south, east, west := adjacent(x, y)
-					d := r.Intn(4)
-					switch (d + i) % 4 {
-					case NORTH:
-						newX, newY = north.x, north.y
-					case SOUTH:
-						newX, newY = south.x, south.y
-					case EAST:
-						newX, newY = east.x, east.y
-					case WEST:
-						newX, newY = west.x, west.y
-					}
-
-					// If an adjacent cell has a fish, eat it.
-					if world[newX][newY] != nil && world[newX][newY].species == FISH {
-						cr.health = *starve // Reset energy after eating.
-
-						// Try to move into the fish's spot (effectively eating it in the next frame).
-						// Note: This simulation logic assumes "first shark to claim the spot gets the fish".
-						if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[newX][newY])), nil, unsafe.Pointer(&cr)) {
-							moved = true
-							// Breeding logic
-							if cr.age > 0 && cr.age%*sBreed == 0 {
-								childEnergy := cr.health / 2
-								cr.health -= childEnergy
-
-								babyShark := &creature{age: 0, health: childEnergy, species: SHARK, asset: sharkcolor, chronon: c}
-								atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(babyShark))
-							}
-							break
-						}
-					}
-				}
-
-				if moved {
-					continue
-				}
-
-				// 3. Movement: If no fish found, move to a random empty spot.
-				for i := 0; i < 4; i++ {
-					north, south, east, west := adjacent(x, y)
-					d := r.Intn(4)
-					switch (d + i) % 4 {
-					case NORTH:
-						newX, newY = north.x, north.y
-					case SOUTH:
-						newX, newY = south.x, south.y
-					case EAST:
-						newX, newY = east.x, east.y
-					case WEST:
-						newX, newY = west.x, west.y
-					}
-
-					if world[newX][newY] == nil {
-						if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[newX][newY])), nil, unsafe.Pointer(&cr)) {
-							moved = true
-							// Breeding logic (even if just moving)
-							if cr.age > 0 && cr.age%*sBreed == 0 {
-								childEnergy := cr.health / 2
-								cr.health -= childEnergy
-
-								babyShark := &creature{age: 0, health: childEnergy, species: SHARK, asset: sharkcolor, chronon: c}
-								atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(babyShark))
-							}
-							break
-						}
-					}
-				}
-
-				// If the shark couldn't move or hunt, it stays in place.
-				if !moved {
-					atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(&cr))
-				}
+			if cr.species == FISH {
+				depositScent(x, y)
 			}
+
+			ctx.X, ctx.Y = x, y
+			speciesFor(cr.species).Step(ctx, &cr)
 		}
 	}
 }
@@ -389,8 +343,15 @@ func adjacent(x, y int) (coordinate, coordinate, coordinate, coordinate) {
 //
 // It allocates the 2D slices for 'world' and 'nextWorld' and randomly populates
 // 'world' with the requested number of Fish and Sharks at random locations.
-// It ensures no two creatures occupy the same starting cell.
+// It ensures no two creatures occupy the same starting cell. It also
+// allocates the scent double buffer (see scent.go) used by sharkSpecies to
+// hunt by gradient once no fish remain adjacent.
+//
+// Placement is drawn from initRand(), the dedicated stream derived from the
+// root seed (see determinism.go), so the starting layout for a given -seed
+// is identical across runs regardless of -threads.
 func initWator() ([][]*creature, [][]*creature) {
+	initScent()
 
 	var wm = make([][]*creature, *wwidth)
 	for i := range wm {
@@ -402,7 +363,9 @@ func initWator() ([][]*creature, [][]*creature) {
 	}
 
 	pop := 0
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fishPlaced := 0
+	sharkPlaced := 0
+	r := initRand()
 
 	// Populate Fish
 	for i := 0; i < *nFish; i++ {
@@ -410,16 +373,17 @@ func initWator() ([][]*creature, [][]*creature) {
 			if pop == *wwidth**wheight {
 				break
 			}
-			x := r.Intn(*wwidth - 1)
-			y := r.Intn(*wheight - 1)
+			x := r.IntN(*wwidth - 1)
+			y := r.IntN(*wheight - 1)
 
 			if wm[x][y] == nil {
 				wm[x][y] = &creature{
-					age:     rand.Intn(*fBreed),
+					age:     r.IntN(*fBreed),
 					species: FISH,
 					asset:   fishcolor,
 				}
 				pop++
+				fishPlaced++
 				break
 			}
 		}
@@ -431,22 +395,24 @@ func initWator() ([][]*creature, [][]*creature) {
 			if pop == *wwidth**wheight {
 				break
 			}
-			x := r.Intn(*wwidth - 1)
-			y := r.Intn(*wheight - 1)
+			x := r.IntN(*wwidth - 1)
+			y := r.IntN(*wheight - 1)
 
 			if wm[x][y] == nil {
 				wm[x][y] = &creature{
-					age:     rand.Intn(*sBreed),
+					age:     r.IntN(*sBreed),
 					species: SHARK,
 					health:  *starve,
 					asset:   sharkcolor,
 				}
 				pop++
+				sharkPlaced++
 				break
 			}
 		}
 	}
 
+	resetPopulationCounts(fishPlaced, sharkPlaced)
 	return wm, nwm
 }
 
@@ -470,8 +436,12 @@ func debug() {
 	}
 }
 
-// Game implements the ebiten.Game interface.
-type Game struct{}
+// Game implements the ebiten.Game interface. Drawing itself is delegated to
+// a Renderer (see render.go) so the GPU upload strategy can be swapped via
+// -renderer without touching Update/Layout.
+type Game struct {
+	renderer Renderer
+}
 
 // Update proceeds the game state.
 // It is called every tick (usually 60 times per second by default in Ebiten).
@@ -479,28 +449,27 @@ type Game struct{}
 func (g *Game) Update() error {
 	tick++
 	Chronon(tick)
+	if activeRecorder != nil {
+		activeRecorder.recordChronon(tick)
+	}
 	return nil
 }
 
-// Draw renders the game screen.
-// It iterates over the world grid and sets pixels based on the creature type.
+// Draw renders the game screen by delegating to g.renderer, then overlays
+// the chronon counter.
 func (g *Game) Draw(screen *ebiten.Image) {
-	screen.Fill(watercolor)
-	for x := 0; x < *wwidth; x++ {
-		for y := 0; y < *wheight; y++ {
-			if world[x][y] != nil {
-				screen.Set(x, y, world[x][y].asset)
-			} else {
-				screen.Set(x, y, watercolor)
-			}
-		}
-	}
+	g.renderer.Draw(screen)
 	ebitenutil.DebugPrint(screen, strconv.Itoa(tick))
 }
 
 // Layout accepts the outside window dimensions and returns the logical game screen size.
-// Here, the logical size matches the simulation grid size exactly.
+// For the pixel renderer that's the simulation grid size exactly; the sprite
+// renderer scales it up by its cell size so each world cell gets its own
+// on-screen square instead of a single pixel.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	if sr, ok := g.renderer.(*SpriteRenderer); ok {
+		return *wwidth * sr.cellSize, *wheight * sr.cellSize
+	}
 	return *wwidth, *wheight
 }
 
@@ -512,27 +481,42 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 // 2. Starts the interactive Ebiten GUI window.
 func main() {
 	flag.Parse()
+	resolveSeed()
+	applyEvolveMode()
+
+	scenario := selectScenario()
 
-	// Ensure the grid is large enough for the initial population.
-	if *nFish+*nSharks > *wwidth**wheight {
+	// Ensure the grid is large enough for the initial population. A
+	// -scenario or -replay file brings its own layout and dimensions, so
+	// this check only applies to the default random placement.
+	if _, ok := scenario.(RandomScenario); ok && *nFish+*nSharks > *wwidth**wheight {
 		log.Fatal("Not enough space for Fish and Shark!")
 	}
 
 	// Set GOMAXPROCS to match the thread count for optimal concurrent execution.
 	runtime.GOMAXPROCS(*nThreads)
 
+	if *recordPath != "" {
+		activeRecorder = newChrononRecorder(*recordPath)
+		defer activeRecorder.Close()
+	}
+
 	if *benchmark {
 		// Headless benchmark mode.
 		fmt.Printf("Running Wa-Tor benchmark...\n")
-		fmt.Printf("Config: Threads=%d, Chronons=%d, Width=%d, Height=%d, Fish=%d, Sharks=%d\n",
-			*nThreads, *chronons, *wwidth, *wheight, *nFish, *nSharks)
+		fmt.Printf("Config: Seed=%d, Threads=%d, Chronons=%d, Width=%d, Height=%d, Fish=%d, Sharks=%d\n",
+			rootSeed, *nThreads, *chronons, *wwidth, *wheight, *nFish, *nSharks)
 
-		world, nextWorld = initWator()
+		world, nextWorld = scenario.Load()
+		startMetricsServer()
 
 		startTime := time.Now()
 
 		for i := 0; i < *chronons; i++ {
 			Chronon(i)
+			if activeRecorder != nil {
+				activeRecorder.recordChronon(i)
+			}
 		}
 
 		duration := time.Since(startTime)
@@ -542,11 +526,14 @@ func main() {
 
 	} else {
 		// Interactive Ebiten GUI mode.
-		world, nextWorld = initWator()
-		ebiten.SetWindowSize(900, 600)
+		world, nextWorld = scenario.Load()
+		startMetricsServer()
+		game := &Game{renderer: newRenderer()}
+		windowWidth, windowHeight := game.Layout(0, 0)
+		ebiten.SetWindowSize(windowWidth, windowHeight)
 		ebiten.SetWindowTitle("Wator")
 
-		if err := ebiten.RunGame(&Game{}); err != nil {
+		if err := ebiten.RunGame(game); err != nil {
 			log.Fatal(err)
 		}
 	}
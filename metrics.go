@@ -0,0 +1,236 @@
+// This file adds an optional HTTP endpoint (-metrics) for watching a run
+// from a browser: /metrics in Prometheus text format, /stats.json with the
+// current counts plus a rolling time series, and /snapshot.png so a
+// headless benchmark can still be eyeballed. Population and birth/death
+// counts are maintained by atomic counters updated at the point a creature
+// is actually created or removed (see recordBirth/recordDeath, called from
+// species.go/evolve.go), rather than by rescanning the grid every chronon.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// metricsAddr, if set, starts the metrics HTTP server on this address (e.g. ":8080").
+var metricsAddr = flag.String("metrics", "", "Address to serve live metrics on (e.g. :8080); empty disables the server.")
+
+// metricsWindow is how many chronons of history /stats.json keeps.
+var metricsWindow = flag.Int("metrics-window", 200, "Number of recent chronons to keep in the /stats.json time series.")
+
+// Population counters, updated incrementally as creatures are born or die
+// rather than by rescanning world. fishAlive/sharkAlive are running totals;
+// the chrononBirths/chrononDeaths pairs are reset to zero at the start of
+// every Chronon and read at the end to build one time-series point.
+var (
+	fishAlive, sharkAlive int64
+
+	chrononFishBirths, chrononSharkBirths                int64
+	chrononFishDeathsPredation, chrononSharkDeathsStarve int64
+)
+
+// recordBirth is called wherever a species' reproduce step creates
+// offspring (fish breeding, shark breeding). The initial population is
+// counted by resetPopulationCounts instead.
+func recordBirth(species int) {
+	switch species {
+	case FISH:
+		atomic.AddInt64(&fishAlive, 1)
+		atomic.AddInt64(&chrononFishBirths, 1)
+	case SHARK:
+		atomic.AddInt64(&sharkAlive, 1)
+		atomic.AddInt64(&chrononSharkBirths, 1)
+	}
+}
+
+// recordDeath is called wherever a creature is removed: a fish eaten by a
+// shark, or a shark starving to death in OnDeath.
+func recordDeath(species int) {
+	switch species {
+	case FISH:
+		atomic.AddInt64(&fishAlive, -1)
+		atomic.AddInt64(&chrononFishDeathsPredation, 1)
+	case SHARK:
+		atomic.AddInt64(&sharkAlive, -1)
+		atomic.AddInt64(&chrononSharkDeathsStarve, 1)
+	}
+}
+
+// resetPopulationCounts sets the running totals after the initial world has
+// been populated by a Scenario, since that placement doesn't go through
+// recordBirth (there's no prior "birth" event to speak of).
+func resetPopulationCounts(fish, shark int) {
+	atomic.StoreInt64(&fishAlive, int64(fish))
+	atomic.StoreInt64(&sharkAlive, int64(shark))
+}
+
+// tickStats is one point in the /stats.json time series.
+type tickStats struct {
+	Chronon             int   `json:"chronon"`
+	Fish                int64 `json:"fish"`
+	Shark               int64 `json:"shark"`
+	FishBirths          int64 `json:"fishBirths"`
+	SharkBirths         int64 `json:"sharkBirths"`
+	FishDeathsPredation int64 `json:"fishDeathsPredation"`
+	SharkDeathsStarve   int64 `json:"sharkDeathsStarve"`
+}
+
+// statsHistory is the rolling window of recent tickStats, guarded by historyMu.
+var (
+	historyMu    sync.Mutex
+	statsHistory []tickStats
+)
+
+// recordChrononStats reads this chronon's birth/death deltas, appends one
+// tickStats entry to the rolling window, and resets the deltas for the next
+// chronon. Chronon calls it once per tick when -metrics is set.
+func recordChrononStats(c int) {
+	entry := tickStats{
+		Chronon:             c,
+		Fish:                atomic.LoadInt64(&fishAlive),
+		Shark:               atomic.LoadInt64(&sharkAlive),
+		FishBirths:          atomic.SwapInt64(&chrononFishBirths, 0),
+		SharkBirths:         atomic.SwapInt64(&chrononSharkBirths, 0),
+		FishDeathsPredation: atomic.SwapInt64(&chrononFishDeathsPredation, 0),
+		SharkDeathsStarve:   atomic.SwapInt64(&chrononSharkDeathsStarve, 0),
+	}
+
+	historyMu.Lock()
+	statsHistory = append(statsHistory, entry)
+	if len(statsHistory) > *metricsWindow {
+		statsHistory = statsHistory[len(statsHistory)-*metricsWindow:]
+	}
+	historyMu.Unlock()
+}
+
+// meanSharkHealth scans world for the average health of living sharks. It's
+// the one metric here that isn't tracked incrementally (health changes on
+// almost every shark step, so an incremental running sum would need a hook
+// at every mutation site for little benefit); it only runs when an HTTP
+// handler actually asks for it, not once per chronon.
+//
+// It holds worldMu for reading, since it runs on an HTTP handler goroutine
+// concurrently with Chronon reassigning world (see main.go).
+func meanSharkHealth() float64 {
+	worldMu.RLock()
+	defer worldMu.RUnlock()
+
+	var sum, count int64
+	for x := range world {
+		for y := range world[x] {
+			if cr := world[x][y]; cr != nil && cr.species == SHARK {
+				sum += int64(cr.health)
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return float64(sum) / float64(count)
+}
+
+// startMetricsServer launches the -metrics HTTP server in the background.
+// It must be called after the initial Scenario has been loaded so the
+// /snapshot.png handler has a world to render.
+func startMetricsServer() {
+	if *metricsAddr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", handlePrometheusMetrics)
+	mux.HandleFunc("/stats.json", handleStatsJSON)
+	mux.HandleFunc("/snapshot.png", handleSnapshotPNG)
+
+	go func() {
+		if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+			log.Printf("metrics: server stopped: %v", err)
+		}
+	}()
+	fmt.Printf("Serving live metrics on %s (/metrics, /stats.json, /snapshot.png)\n", *metricsAddr)
+}
+
+func handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP wator_fish_alive Current number of live fish.\n")
+	fmt.Fprintf(w, "# TYPE wator_fish_alive gauge\n")
+	fmt.Fprintf(w, "wator_fish_alive %d\n", atomic.LoadInt64(&fishAlive))
+	fmt.Fprintf(w, "# HELP wator_shark_alive Current number of live sharks.\n")
+	fmt.Fprintf(w, "# TYPE wator_shark_alive gauge\n")
+	fmt.Fprintf(w, "wator_shark_alive %d\n", atomic.LoadInt64(&sharkAlive))
+	fmt.Fprintf(w, "# HELP wator_shark_health_mean Mean health of living sharks.\n")
+	fmt.Fprintf(w, "# TYPE wator_shark_health_mean gauge\n")
+	fmt.Fprintf(w, "wator_shark_health_mean %f\n", meanSharkHealth())
+	fmt.Fprintf(w, "# HELP wator_tick Current chronon.\n")
+	fmt.Fprintf(w, "# TYPE wator_tick counter\n")
+	fmt.Fprintf(w, "wator_tick %d\n", tick)
+}
+
+func handleStatsJSON(w http.ResponseWriter, r *http.Request) {
+	historyMu.Lock()
+	history := make([]tickStats, len(statsHistory))
+	copy(history, statsHistory)
+	historyMu.Unlock()
+
+	resp := struct {
+		Tick            int         `json:"tick"`
+		Fish            int64       `json:"fish"`
+		Shark           int64       `json:"shark"`
+		MeanSharkHealth float64     `json:"meanSharkHealth"`
+		History         []tickStats `json:"history"`
+	}{
+		Tick:            tick,
+		Fish:            atomic.LoadInt64(&fishAlive),
+		Shark:           atomic.LoadInt64(&sharkAlive),
+		MeanSharkHealth: meanSharkHealth(),
+		History:         history,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleSnapshotPNG renders the current world to PNG using the same
+// per-cell fill logic as PixelRenderer, but against a plain image.RGBA
+// instead of an ebiten.Image, since ebiten's GPU-backed images can only be
+// touched from the goroutine driving RunGame, not from an arbitrary HTTP
+// handler goroutine.
+//
+// It holds worldMu for reading, since it runs on an HTTP handler goroutine
+// concurrently with Chronon reassigning world (see main.go).
+func handleSnapshotPNG(w http.ResponseWriter, r *http.Request) {
+	worldMu.RLock()
+	img := image.NewRGBA(image.Rect(0, 0, *wwidth, *wheight))
+	for x := 0; x < *wwidth; x++ {
+		for y := 0; y < *wheight; y++ {
+			c := watercolor
+			if cr := world[x][y]; cr != nil {
+				c = cr.asset
+			}
+			// watercolor/fishcolor/sharkcolor all carry alpha=0, which would
+			// otherwise PNG-encode every pixel fully transparent; force full
+			// alpha, same as PixelRenderer.Draw does for the live GUI.
+			img.SetRGBA(x, y, color.RGBA{c.R, c.G, c.B, 0xff})
+		}
+	}
+	worldMu.RUnlock()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,118 @@
+// This file implements the tiny feed-forward networks used by -evolve mode:
+// a shark's hunting policy is a set of weights carried on its own creature
+// struct (so it can be copied to offspring and mutated) rather than a
+// single global policy, which is what lets sharks with better policies
+// out-survive and out-reproduce worse ones.
+package main
+
+import "math/rand/v2"
+
+// Network shape: 8 inputs (species+age for each of the 4 neighbors), one
+// hidden layer of 6 ReLU units, 4 outputs (one logit per move direction).
+const (
+	brainInputs  = 8
+	brainHidden  = 6
+	brainOutputs = 4
+
+	brainWeightCount = brainHidden*brainInputs + brainHidden + brainOutputs*brainHidden + brainOutputs
+)
+
+// brain is a view over a creature's flat weights slice, split into the two
+// layers' weights and biases. It holds no storage of its own so that
+// reproduction can cheaply copy/mutate the underlying []float32 without
+// re-packing it into a struct each time.
+type brain struct {
+	w1, b1 []float32 // hidden layer: w1 is brainHidden x brainInputs, b1 is brainHidden
+	w2, b2 []float32 // output layer: w2 is brainOutputs x brainHidden, b2 is brainOutputs
+}
+
+// asBrain slices a flat weights vector (as stored on creature.weights) into
+// its four named parts. flat must have length brainWeightCount.
+func asBrain(flat []float32) brain {
+	i := 0
+	w1 := flat[i : i+brainHidden*brainInputs]
+	i += brainHidden * brainInputs
+	b1 := flat[i : i+brainHidden]
+	i += brainHidden
+	w2 := flat[i : i+brainOutputs*brainHidden]
+	i += brainOutputs * brainHidden
+	b2 := flat[i : i+brainOutputs]
+	return brain{w1: w1, b1: b1, w2: w2, b2: b2}
+}
+
+// newBrainWeights returns a freshly randomized flat weights vector, used to
+// give a shark spawned outside of reproduction (the initial population) a
+// starting policy.
+func newBrainWeights(r *rand.Rand) []float32 {
+	flat := make([]float32, brainWeightCount)
+	for i := range flat {
+		flat[i] = float32(r.NormFloat64())
+	}
+	return flat
+}
+
+// forward runs the network on inputs and returns one logit per move
+// direction (NORTH, SOUTH, EAST, WEST); the caller picks argmax.
+func (b brain) forward(inputs [brainInputs]float32) [brainOutputs]float32 {
+	var hidden [brainHidden]float32
+	for i := 0; i < brainHidden; i++ {
+		sum := b.b1[i]
+		for j := 0; j < brainInputs; j++ {
+			sum += b.w1[i*brainInputs+j] * inputs[j]
+		}
+		if sum < 0 {
+			sum = 0 // ReLU
+		}
+		hidden[i] = sum
+	}
+
+	var out [brainOutputs]float32
+	for k := 0; k < brainOutputs; k++ {
+		sum := b.b2[k]
+		for i := 0; i < brainHidden; i++ {
+			sum += b.w2[k*brainHidden+i] * hidden[i]
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// argmax returns the index of the largest logit.
+func argmax(logits [brainOutputs]float32) int {
+	best := 0
+	for i := 1; i < brainOutputs; i++ {
+		if logits[i] > logits[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// mutate returns a child weights vector: a copy of parent with each weight
+// perturbed by Gaussian noise (stddev) with independent probability rate.
+func mutate(parent []float32, rate, stddev float64, r *rand.Rand) []float32 {
+	child := make([]float32, len(parent))
+	copy(child, parent)
+	for i := range child {
+		if r.Float64() < rate {
+			child[i] += float32(r.NormFloat64() * stddev)
+		}
+	}
+	return child
+}
+
+// crossover returns a child weights vector built by picking each weight
+// uniformly at random from one of the two parents. Wa-Tor reproduction is
+// asexual (one parent per offspring) so the default -evolve policy never
+// calls this, but it's here for anyone wiring up two-parent breeding.
+func crossover(a, b []float32, r *rand.Rand) []float32 {
+	child := make([]float32, len(a))
+	for i := range child {
+		if r.IntN(2) == 0 {
+			child[i] = a[i]
+		} else {
+			child[i] = b[i]
+		}
+	}
+	return child
+}
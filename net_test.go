@@ -0,0 +1,103 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// TestForwardDeterministic asserts that forward is a pure function of its
+// weights and inputs, which reproduction (copy the parent's weights onto the
+// child creature) relies on: two brains built from the same flat weights
+// must answer identically given the same inputs.
+func TestForwardDeterministic(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	weights := newBrainWeights(r)
+	inputs := [brainInputs]float32{0, 1, 2, 3, 0, -1, -2, -3}
+
+	b1 := asBrain(weights)
+	b2 := asBrain(weights)
+
+	out1 := b1.forward(inputs)
+	out2 := b2.forward(inputs)
+	if out1 != out2 {
+		t.Fatalf("forward is not deterministic: %v vs %v", out1, out2)
+	}
+}
+
+// TestForwardReLUClampsHiddenLayer asserts the hidden layer's ReLU actually
+// zeroes negative pre-activations: with all weights zero, every hidden bias
+// set to -1 and the output biases set to 1..4, every hidden unit should be
+// clamped to zero and so the outputs should equal the output-layer biases alone.
+func TestForwardReLUClampsHiddenLayer(t *testing.T) {
+	flat := make([]float32, brainWeightCount)
+	b := asBrain(flat)
+	for i := range b.b1 {
+		b.b1[i] = -1 // every hidden pre-activation is negative regardless of input.
+	}
+	for i := range b.b2 {
+		b.b2[i] = float32(i + 1)
+	}
+
+	out := b.forward([brainInputs]float32{1, 1, 1, 1, 1, 1, 1, 1})
+	for i, v := range out {
+		want := float32(i + 1)
+		if v != want {
+			t.Fatalf("output[%d] = %v, want %v (hidden layer should have been clamped to zero)", i, v, want)
+		}
+	}
+}
+
+// TestMutateLeavesParentUntouched asserts mutate returns a fresh slice and
+// never writes through to the parent, since the parent creature keeps
+// acting on its own weights after spawning a mutated child.
+func TestMutateLeavesParentUntouched(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	parent := newBrainWeights(r)
+	original := append([]float32(nil), parent...)
+
+	child := mutate(parent, 1.0, 1.0, r)
+
+	for i := range parent {
+		if parent[i] != original[i] {
+			t.Fatalf("mutate modified the parent's weights at index %d", i)
+		}
+	}
+	if len(child) != len(parent) {
+		t.Fatalf("child length = %d, want %d", len(child), len(parent))
+	}
+}
+
+// TestMutateZeroRateIsIdentity asserts a rate of 0 never perturbs a weight,
+// since -evolve callers rely on rate to control how much drift a generation
+// introduces.
+func TestMutateZeroRateIsIdentity(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	parent := newBrainWeights(r)
+
+	child := mutate(parent, 0, 1.0, r)
+
+	for i := range parent {
+		if child[i] != parent[i] {
+			t.Fatalf("child[%d] = %v, want unchanged %v", i, child[i], parent[i])
+		}
+	}
+}
+
+// TestCrossoverPicksFromEitherParent asserts every gene in the child came
+// from one of the two parents, not some blended or out-of-range value.
+func TestCrossoverPicksFromEitherParent(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	a := newBrainWeights(r)
+	b := newBrainWeights(r)
+
+	child := crossover(a, b, r)
+
+	if len(child) != len(a) {
+		t.Fatalf("child length = %d, want %d", len(child), len(a))
+	}
+	for i := range child {
+		if child[i] != a[i] && child[i] != b[i] {
+			t.Fatalf("child[%d] = %v, matches neither parent (%v, %v)", i, child[i], a[i], b[i])
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// This file replaces the original per-pixel screen.Set calls in Game.Draw
+// with batched GPU uploads. PixelRenderer uploads one RGBA buffer per frame
+// via ebiten.Image.WritePixels (an order of magnitude faster than Set at
+// 900x600+), and SpriteRenderer additionally lets each cell be drawn at a
+// configurable pixel size, optionally from a user-supplied sprite atlas,
+// which both improves large-grid performance and leaves room for zoom/pan
+// since cell size is now independent of the simulation grid's resolution.
+package main
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Rendering configuration flags.
+var (
+	// rendererName selects the Renderer implementation: "pixel" or "sprite".
+	rendererName = flag.String("renderer", "pixel", "Rendering backend to use in GUI mode: pixel|sprite.")
+
+	// cellSize is the on-screen pixel size of one world cell; only the sprite renderer honors values above 1.
+	cellSize = flag.Int("cellsize", 1, "Pixel size of one world cell when using the sprite renderer.")
+
+	// atlasPath, if set, is a PNG atlas of fish/shark tiles for the sprite renderer; left blank, it falls back to flat-colored tiles.
+	atlasPath = flag.String("atlas", "", "Path to a PNG sprite atlas (fish tile then shark tile, left to right) for the sprite renderer.")
+)
+
+// Renderer draws the current world to the screen. Game holds one and
+// delegates every Draw call to it, so adding a new backend never touches
+// Game itself.
+type Renderer interface {
+	Draw(screen *ebiten.Image)
+}
+
+// newRenderer builds the Renderer selected by -renderer.
+func newRenderer() Renderer {
+	if *rendererName == "sprite" {
+		return newSpriteRenderer()
+	}
+	return newPixelRenderer()
+}
+
+// PixelRenderer fills a persistent RGBA byte buffer once per frame and
+// uploads it in a single WritePixels call, instead of calling screen.Set
+// once per cell.
+type PixelRenderer struct {
+	img *ebiten.Image
+	buf []byte
+}
+
+func newPixelRenderer() *PixelRenderer {
+	return &PixelRenderer{
+		img: ebiten.NewImage(*wwidth, *wheight),
+		buf: make([]byte, 4**wwidth**wheight),
+	}
+}
+
+func (p *PixelRenderer) Draw(screen *ebiten.Image) {
+	for x := 0; x < *wwidth; x++ {
+		for y := 0; y < *wheight; y++ {
+			c := watercolor
+			if cr := world[x][y]; cr != nil {
+				c = cr.asset
+			}
+			i := (y**wwidth + x) * 4
+			p.buf[i], p.buf[i+1], p.buf[i+2], p.buf[i+3] = c.R, c.G, c.B, 0xff
+		}
+	}
+	p.img.WritePixels(p.buf)
+	screen.DrawImage(p.img, nil)
+}
+
+// spriteTileSize is the width and height, in pixels, of one tile in an
+// atlas supplied via -atlas.
+const spriteTileSize = 16
+
+// SpriteRenderer draws each occupied cell as a scaled sprite at
+// *cellSize pixels, either a tile from a user atlas or, when -atlas is
+// unset, a flat-colored square using the species' asset color.
+type SpriteRenderer struct {
+	atlas    *ebiten.Image
+	fallback *ebiten.Image // 1x1 white pixel, tinted and scaled per cell when atlas is nil
+	cellSize int
+}
+
+func newSpriteRenderer() *SpriteRenderer {
+	cs := *cellSize
+	if cs < 1 {
+		cs = 1
+	}
+	sr := &SpriteRenderer{cellSize: cs}
+
+	if *atlasPath != "" {
+		atlas, _, err := ebitenutil.NewImageFromFile(*atlasPath)
+		if err != nil {
+			log.Fatalf("renderer: loading atlas %s: %v", *atlasPath, err)
+		}
+		sr.atlas = atlas
+	} else {
+		img := ebiten.NewImage(1, 1)
+		img.Fill(color.White)
+		sr.fallback = img
+	}
+	return sr
+}
+
+// tileRect returns the atlas sub-rectangle for a species: fish first, shark second.
+func tileRect(species int) image.Rectangle {
+	x0 := species * spriteTileSize
+	return image.Rect(x0, 0, x0+spriteTileSize, spriteTileSize)
+}
+
+func (s *SpriteRenderer) Draw(screen *ebiten.Image) {
+	screen.Fill(watercolor)
+
+	for x := 0; x < *wwidth; x++ {
+		for y := 0; y < *wheight; y++ {
+			cr := world[x][y]
+			if cr == nil {
+				continue
+			}
+
+			opts := &ebiten.DrawImageOptions{}
+
+			if s.atlas != nil {
+				scale := float64(s.cellSize) / spriteTileSize
+				opts.GeoM.Scale(scale, scale)
+				opts.GeoM.Translate(float64(x*s.cellSize), float64(y*s.cellSize))
+				tile := s.atlas.SubImage(tileRect(cr.species)).(*ebiten.Image)
+				screen.DrawImage(tile, opts)
+				continue
+			}
+
+			opts.GeoM.Scale(float64(s.cellSize), float64(s.cellSize))
+			opts.GeoM.Translate(float64(x*s.cellSize), float64(y*s.cellSize))
+			opts.ColorScale.ScaleWithColor(cr.asset)
+			// fishcolor/sharkcolor carry alpha=0 (see the color constants
+			// above); ScaleWithColor would otherwise scale this sprite fully
+			// transparent, so force full alpha, same as PixelRenderer.Draw
+			// hardcodes p.buf[i+3] = 0xff above.
+			opts.ColorScale.SetA(1)
+			screen.DrawImage(s.fallback, opts)
+		}
+	}
+}
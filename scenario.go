@@ -0,0 +1,304 @@
+// This file adds a scenario subsystem so the initial world state can come
+// from a JSON description instead of only random placement, and so a run
+// can be recorded to a binary log and replayed later. This lets users share
+// an interesting layout or an interesting run and have others reproduce it
+// exactly, which plain -seed (see determinism.go) only gets you if nobody
+// touches -fish/-sharks/-width/-height in between.
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+)
+
+// Scenario flags.
+var (
+	// scenarioPath, if set, loads the initial world from a JSON file instead of randomly placing -fish/-sharks creatures.
+	scenarioPath = flag.String("scenario", "", "Path to a JSON scenario file describing the initial world state.")
+
+	// recordPath, if set, appends every chronon's occupied-cell snapshot to a binary log for later replay.
+	recordPath = flag.String("record", "", "Path to write a binary chronon log as the simulation runs.")
+
+	// replayPath, if set, plays back a previously recorded log instead of computing new chronons.
+	replayPath = flag.String("replay", "", "Path to a binary chronon log to play back instead of simulating.")
+)
+
+// Scenario produces the initial world/nextWorld grids for a run. initWator's
+// random placement, JSON-file placement, and log playback are each one
+// implementation, selected in main() based on the -scenario/-replay flags.
+type Scenario interface {
+	Load() ([][]*creature, [][]*creature)
+}
+
+// RandomScenario is the default Scenario: it places -fish/-sharks creatures
+// uniformly at random, exactly as initWator always has.
+type RandomScenario struct{}
+
+func (RandomScenario) Load() ([][]*creature, [][]*creature) {
+	return initWator()
+}
+
+// sceneCreature is the JSON/binary-log representation of a single creature.
+type sceneCreature struct {
+	X, Y, Species, Age, Health int
+}
+
+// sceneFile is the JSON schema accepted by -scenario: grid dimensions,
+// breed/starve parameters, and the initial creature list. Any parameter left
+// at its zero value keeps whatever was already set by the corresponding
+// flag, so a scenario file only needs to declare what it wants to override.
+type sceneFile struct {
+	Width, Height  int
+	FBreed, SBreed int
+	Starve         int
+	Creatures      []sceneCreature
+}
+
+// FileScenario loads the initial world from a JSON file (see sceneFile).
+// Tiled .tmx maps are not parsed directly; export a Tiled map's object layer
+// to this JSON shape first.
+type FileScenario struct {
+	Path string
+}
+
+func (f *FileScenario) Load() ([][]*creature, [][]*creature) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		log.Fatalf("scenario: reading %s: %v", f.Path, err)
+	}
+
+	var sf sceneFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		log.Fatalf("scenario: parsing %s: %v", f.Path, err)
+	}
+
+	if sf.Width > 0 {
+		*wwidth = sf.Width
+	}
+	if sf.Height > 0 {
+		*wheight = sf.Height
+	}
+	if sf.FBreed > 0 {
+		*fBreed = sf.FBreed
+	}
+	if sf.SBreed > 0 {
+		*sBreed = sf.SBreed
+	}
+	if sf.Starve > 0 {
+		*starve = sf.Starve
+	}
+
+	initScent()
+
+	wm := make([][]*creature, *wwidth)
+	nwm := make([][]*creature, *wwidth)
+	for i := range wm {
+		wm[i] = make([]*creature, *wheight)
+		nwm[i] = make([]*creature, *wheight)
+	}
+
+	fishPlaced, sharkPlaced := 0, 0
+	for _, sc := range sf.Creatures {
+		if sc.X < 0 || sc.X >= *wwidth || sc.Y < 0 || sc.Y >= *wheight {
+			log.Fatalf("scenario: %s: creature at (%d, %d) is out of bounds for a %dx%d world", f.Path, sc.X, sc.Y, *wwidth, *wheight)
+		}
+		if speciesFor(sc.Species) == nil {
+			log.Fatalf("scenario: %s: creature at (%d, %d) has unrecognized species %d", f.Path, sc.X, sc.Y, sc.Species)
+		}
+		asset := fishcolor
+		if sc.Species == SHARK {
+			asset = sharkcolor
+			sharkPlaced++
+		} else {
+			fishPlaced++
+		}
+		wm[sc.X][sc.Y] = &creature{age: sc.Age, health: sc.Health, species: sc.Species, asset: asset}
+	}
+	resetPopulationCounts(fishPlaced, sharkPlaced)
+
+	return wm, nwm
+}
+
+// logMagic identifies a Wa-Tor chronon log file; logVersion lets the format
+// evolve without breaking old recordings.
+const (
+	logMagic   = "WLOG"
+	logVersion = 1
+)
+
+// chrononRecorder appends an occupied-cell snapshot of the world to a binary
+// log after every chronon, when -record is set. It is not a true diff
+// against the previous frame (recomputing one would need to walk both
+// grids anyway); recording the occupied cells is already compact for the
+// sparse populations Wa-Tor runs with.
+type chrononRecorder struct {
+	w *bufio.Writer
+	f *os.File
+}
+
+func newChrononRecorder(path string) *chrononRecorder {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("record: creating %s: %v", path, err)
+	}
+	w := bufio.NewWriter(f)
+	io.WriteString(w, logMagic)
+	binary.Write(w, binary.LittleEndian, uint32(logVersion))
+	binary.Write(w, binary.LittleEndian, uint32(*wwidth))
+	binary.Write(w, binary.LittleEndian, uint32(*wheight))
+	return &chrononRecorder{w: w, f: f}
+}
+
+// recordChronon writes the current world's occupied cells as one frame.
+func (r *chrononRecorder) recordChronon(c int) {
+	var cells []sceneCreature
+	for x := 0; x < *wwidth; x++ {
+		for y := 0; y < *wheight; y++ {
+			if cr := world[x][y]; cr != nil {
+				cells = append(cells, sceneCreature{X: x, Y: y, Species: cr.species, Age: cr.age, Health: cr.health})
+			}
+		}
+	}
+
+	binary.Write(r.w, binary.LittleEndian, uint32(c))
+	binary.Write(r.w, binary.LittleEndian, uint32(len(cells)))
+	for _, cell := range cells {
+		binary.Write(r.w, binary.LittleEndian, uint32(cell.X))
+		binary.Write(r.w, binary.LittleEndian, uint32(cell.Y))
+		binary.Write(r.w, binary.LittleEndian, uint32(cell.Species))
+		binary.Write(r.w, binary.LittleEndian, uint32(cell.Age))
+		binary.Write(r.w, binary.LittleEndian, uint32(cell.Health))
+	}
+}
+
+func (r *chrononRecorder) Close() {
+	r.w.Flush()
+	r.f.Close()
+}
+
+// chrononReplayer reads frames written by chrononRecorder back out in
+// order; ReplayScenario uses it to seed the initial grid and Chronon uses it
+// to serve every subsequent tick instead of computing one.
+type chrononReplayer struct {
+	r *bufio.Reader
+	f *os.File
+}
+
+func newChrononReplayer(path string) *chrononReplayer {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("replay: opening %s: %v", path, err)
+	}
+	r := bufio.NewReader(f)
+
+	magic := make([]byte, len(logMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != logMagic {
+		log.Fatalf("replay: %s is not a Wa-Tor chronon log", path)
+	}
+	var version, w, h uint32
+	binary.Read(r, binary.LittleEndian, &version)
+	binary.Read(r, binary.LittleEndian, &w)
+	binary.Read(r, binary.LittleEndian, &h)
+	*wwidth, *wheight = int(w), int(h)
+
+	return &chrononReplayer{r: r, f: f}
+}
+
+// next reads the next recorded frame into a fresh occupied-cell grid, or
+// returns ok=false once the log is exhausted.
+func (r *chrononReplayer) next() (grid [][]*creature, ok bool) {
+	var c, count uint32
+	if err := binary.Read(r.r, binary.LittleEndian, &c); err != nil {
+		return nil, false
+	}
+	if err := binary.Read(r.r, binary.LittleEndian, &count); err != nil {
+		return nil, false
+	}
+
+	grid = make([][]*creature, *wwidth)
+	for i := range grid {
+		grid[i] = make([]*creature, *wheight)
+	}
+
+	for i := uint32(0); i < count; i++ {
+		var x, y, species, age, health uint32
+		binary.Read(r.r, binary.LittleEndian, &x)
+		binary.Read(r.r, binary.LittleEndian, &y)
+		binary.Read(r.r, binary.LittleEndian, &species)
+		binary.Read(r.r, binary.LittleEndian, &age)
+		binary.Read(r.r, binary.LittleEndian, &health)
+
+		asset := fishcolor
+		if int(species) == SHARK {
+			asset = sharkcolor
+		}
+		grid[x][y] = &creature{age: int(age), health: int(health), species: int(species), asset: asset, chronon: int(c)}
+	}
+	return grid, true
+}
+
+func (r *chrononReplayer) Close() {
+	r.f.Close()
+}
+
+// ReplayScenario loads the first frame of a chronon log as the initial
+// world; Chronon then pulls every subsequent frame from the same replayer
+// instead of simulating (see activeReplayer below and Chronon in main.go).
+type ReplayScenario struct {
+	Path string
+}
+
+func (rs *ReplayScenario) Load() ([][]*creature, [][]*creature) {
+	activeReplayer = newChrononReplayer(rs.Path)
+	first, ok := activeReplayer.next()
+	if !ok {
+		log.Fatalf("replay: %s contains no frames", rs.Path)
+	}
+	nwm := make([][]*creature, *wwidth)
+	for i := range nwm {
+		nwm[i] = make([]*creature, *wheight)
+	}
+	initScent()
+
+	fishPlaced, sharkPlaced := 0, 0
+	for x := range first {
+		for y := range first[x] {
+			if cr := first[x][y]; cr != nil {
+				if cr.species == SHARK {
+					sharkPlaced++
+				} else {
+					fishPlaced++
+				}
+			}
+		}
+	}
+	resetPopulationCounts(fishPlaced, sharkPlaced)
+
+	return first, nwm
+}
+
+// activeReplayer is non-nil for the lifetime of the process when running in
+// replay mode; Chronon checks it before doing any real work.
+var activeReplayer *chrononReplayer
+
+// activeRecorder is non-nil for the lifetime of the process when -record is
+// set; main() closes it on exit.
+var activeRecorder *chrononRecorder
+
+// selectScenario picks the Scenario implementation based on -replay,
+// -scenario, and the default random placement, in that priority order.
+func selectScenario() Scenario {
+	switch {
+	case *replayPath != "":
+		return &ReplayScenario{Path: *replayPath}
+	case *scenarioPath != "":
+		return &FileScenario{Path: *scenarioPath}
+	default:
+		return RandomScenario{}
+	}
+}
@@ -0,0 +1,102 @@
+// This file implements the fish "scent" field used by sharkSpecies when no
+// fish is directly adjacent: fish deposit scent into their own cell each
+// chronon, and the field diffuses to neighbors and evaporates over time,
+// giving sharks with no prey in reach a gradient to climb instead of
+// wandering uniformly at random.
+package main
+
+import (
+	"flag"
+	"sync"
+)
+
+// Scent configuration flags.
+var (
+	// scentDeposit is how much scent a fish adds to its own cell each chronon.
+	scentDeposit = flag.Float64("scent-deposit", 1.0, "Amount of scent a fish deposits into its cell each chronon.")
+
+	// scentEvaporation is the fraction of scent lost each chronon (0-1).
+	scentEvaporation = flag.Float64("scent-evaporation", 0.1, "Fraction of scent that evaporates each chronon.")
+
+	// scentDiffusion is the fraction of a cell's scent shared with its neighbors each chronon.
+	scentDiffusion = flag.Float64("scent-diffusion", 0.2, "Fraction of scent diffused to neighboring cells each chronon.")
+
+	// scentTemperature controls how sharply sharks favor the strongest neighbor scent.
+	scentTemperature = flag.Float64("scent-temperature", 1.0, "Softmax temperature sharks use to pick a neighbor by scent.")
+)
+
+// scentField is the current scent grid, same shape as world; nil means
+// scent tracking is disabled (sharks fall back to uniform random wandering).
+var scentField [][]float32
+
+// nextScentField is the write buffer for the scent diffusion pass, swapped
+// with scentField alongside world/nextWorld in Chronon.
+var nextScentField [][]float32
+
+// initScent allocates the scent double buffer. It is called by every
+// Scenario's Load (initWator, FileScenario, ReplayScenario), so scent
+// tracking is always on once a world has been loaded.
+func initScent() {
+	scentField = make([][]float32, *wwidth)
+	nextScentField = make([][]float32, *wwidth)
+	for i := range scentField {
+		scentField[i] = make([]float32, *wheight)
+		nextScentField[i] = make([]float32, *wheight)
+	}
+}
+
+// depositScent records that a fish currently occupies (x, y); called from
+// updateSlice while processing a FISH creature.
+func depositScent(x, y int) {
+	if scentField == nil {
+		return
+	}
+	scentField[x][y] += float32(*scentDeposit)
+}
+
+// diffuseScent runs diffuseScentSlice across the same fixed numBlocks
+// partition updateSlice used this chronon, on a pool of numWorkers
+// goroutines. It must only be called after Chronon's movement-phase
+// wg.Wait() — depositScent writes during updateSlice and diffuseScentSlice's
+// neighbor reads both touch scentField, and a block's neighbors can belong
+// to another goroutine's block, so running this as its own phase behind a
+// full barrier (rather than interleaved per-block on the same worker, as a
+// prior version of this function did) is what keeps it race-free.
+func diffuseScent(numBlocks, numWorkers int) {
+	blocks := make(chan int, numBlocks)
+	for b := 0; b < numBlocks; b++ {
+		blocks <- b
+	}
+	close(blocks)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for block := range blocks {
+				startY, endY := blockBounds(block, numBlocks)
+				diffuseScentSlice(startY, endY)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// diffuseScentSlice evaporates and diffuses the scent field for rows
+// [startY, endY), writing into nextScentField. diffuseScent calls it once
+// per block of the same partition updateSlice uses.
+func diffuseScentSlice(startY, endY int) {
+	evap := float32(*scentEvaporation)
+	alpha := float32(1 - *scentDiffusion)
+	beta := float32(*scentDiffusion) / 4
+
+	for y := startY; y < endY; y++ {
+		for x := 0; x < *wwidth; x++ {
+			n, s, e, w := adjacent(x, y)
+			neighborSum := scentField[n.x][n.y] + scentField[s.x][s.y] + scentField[e.x][e.y] + scentField[w.x][w.y]
+			blended := alpha*scentField[x][y] + beta*neighborSum
+			nextScentField[x][y] = (1 - evap) * blended
+		}
+	}
+}
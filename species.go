@@ -0,0 +1,266 @@
+// This file defines the pluggable Species engine that replaced the original
+// hard-coded FISH/SHARK switch in updateSlice. A Species owns the rules for
+// how one kind of creature steps, reproduces, and reacts to death; new
+// species can be added by implementing the interface and registering it,
+// without touching Chronon or updateSlice itself.
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"unsafe"
+)
+
+// StepCtx carries everything a Species needs to evaluate one creature during
+// a single chronon: the coordinate being processed, the chronon index, and
+// the RNG stream of the block being processed. The grids and the scent
+// field are read through package globals, not through StepCtx.
+type StepCtx struct {
+	X, Y    int
+	Chronon int
+	Rand    *rand.Rand
+}
+
+// Species implements the behavior of one kind of creature: how it moves or
+// hunts (Step), how it spawns offspring (Reproduce), and any bookkeeping
+// needed when it dies (OnDeath). Implementations must be safe to call
+// concurrently from multiple updateSlice goroutines, each operating on a
+// disjoint set of coordinates.
+type Species interface {
+	// Step advances cr by one chronon at ctx.X, ctx.Y and attempts to place
+	// it (or leave it in place) in nextWorld. It returns ok=true if the
+	// creature survives, or ok=false if it died.
+	Step(ctx *StepCtx, cr *creature) (ok bool)
+
+	// Reproduce is called by Step after a creature moves; it returns the
+	// offspring to leave behind in the vacated cell, or nil if the creature
+	// is not eligible to breed this chronon.
+	Reproduce(cr *creature) *creature
+
+	// OnDeath is invoked when a creature fails to survive Step, e.g. a
+	// starved shark. fishSpecies ignores it; sharkSpecies uses it to count
+	// the death for the -metrics counters (see metrics.go).
+	OnDeath(cr *creature)
+
+	// Color returns the asset used to render this species.
+	Color() color.RGBA
+}
+
+// SpeciesRegistry maps a species id to its behavior. It is populated once at
+// startup (see init() below and RegisterSpecies) and read concurrently by
+// every updateSlice goroutine thereafter, so it must not be mutated once the
+// simulation is running.
+var speciesRegistry = map[int]Species{}
+
+// RegisterSpecies installs s as the behavior for species id. Call it from an
+// init() func (or before main's flag.Parse completes) to add a new trophic
+// level without modifying updateSlice.
+func RegisterSpecies(id int, s Species) {
+	speciesRegistry[id] = s
+}
+
+// speciesFor looks up the registered behavior for a creature's species id.
+func speciesFor(id int) Species {
+	return speciesRegistry[id]
+}
+
+func init() {
+	RegisterSpecies(FISH, &fishSpecies{})
+	RegisterSpecies(SHARK, newSharkSpecies())
+}
+
+// cas attempts to atomically claim nextWorld[x][y] for cr, returning true on
+// success. It is shared by every Species implementation so CAS semantics
+// stay consistent with the original updateSlice.
+func cas(x, y int, cr *creature) bool {
+	return atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(cr))
+}
+
+// pickDirection returns the neighbor of (ctx.X, ctx.Y) in direction
+// (d+i)%4, where d is a fresh random offset drawn on every call, so callers
+// probing i = 0..3 don't favor NORTH. Successive calls draw independent
+// offsets, so such a probe can see one direction more than once.
+func pickDirection(ctx *StepCtx, i int) (int, int) {
+	north, south, east, west := adjacent(ctx.X, ctx.Y)
+	d := ctx.Rand.IntN(4)
+	switch (d + i) % 4 {
+	case NORTH:
+		return north.x, north.y
+	case SOUTH:
+		return south.x, south.y
+	case EAST:
+		return east.x, east.y
+	default:
+		return west.x, west.y
+	}
+}
+
+// fishSpecies implements the classic Wa-Tor fish: move to a random empty
+// neighbor, breed after fBreed chronons.
+type fishSpecies struct{}
+
+func (s *fishSpecies) Color() color.RGBA { return fishcolor }
+
+func (s *fishSpecies) Step(ctx *StepCtx, cr *creature) bool {
+	for i := 0; i < 4; i++ {
+		nx, ny := pickDirection(ctx, i)
+		if world[nx][ny] != nil {
+			continue
+		}
+		if !cas(nx, ny, cr) {
+			continue
+		}
+		if baby := s.Reproduce(cr); baby != nil {
+			cas(ctx.X, ctx.Y, baby)
+		}
+		return true
+	}
+	// Couldn't move; stay put.
+	cas(ctx.X, ctx.Y, cr)
+	return true
+}
+
+func (s *fishSpecies) Reproduce(cr *creature) *creature {
+	if cr.age > 0 && cr.age%*fBreed == 0 {
+		recordBirth(FISH)
+		return &creature{age: 0, species: FISH, asset: fishcolor, chronon: cr.chronon}
+	}
+	return nil
+}
+
+func (s *fishSpecies) OnDeath(cr *creature) {}
+
+// sharkSpecies implements the classic shark: hunt an adjacent fish, else
+// wander to an empty cell, starve if health drops to zero. When scent
+// tracking is enabled (see scent.go) and no fish is adjacent, the shark
+// samples its next square from a softmax over neighbor scent instead of
+// moving uniformly at random; this is the PheromoneShark behavior described
+// in the request, folded into the default shark rather than kept separate so
+// the existing flags keep working unchanged.
+type sharkSpecies struct {
+	// temperature controls how sharply the softmax favors the strongest
+	// scent neighbor; lower values are closer to argmax, higher values
+	// closer to uniform random.
+	temperature float64
+}
+
+func newSharkSpecies() *sharkSpecies {
+	return &sharkSpecies{temperature: *scentTemperature}
+}
+
+func (s *sharkSpecies) Color() color.RGBA { return sharkcolor }
+
+func (s *sharkSpecies) Step(ctx *StepCtx, cr *creature) bool {
+	cr.health--
+	if cr.health <= 0 {
+		s.OnDeath(cr)
+		return false
+	}
+
+	// Hunt: prefer an adjacent fish.
+	for i := 0; i < 4; i++ {
+		nx, ny := pickDirection(ctx, i)
+		if world[nx][ny] == nil || world[nx][ny].species != FISH {
+			continue
+		}
+		cr.health = *starve
+		if !cas(nx, ny, cr) {
+			continue
+		}
+		recordDeath(FISH)
+		if baby := s.Reproduce(cr); baby != nil {
+			cas(ctx.X, ctx.Y, baby)
+		}
+		return true
+	}
+
+	// No adjacent fish: follow the scent gradient if enabled, else wander.
+	if scentField != nil {
+		if s.stepByScent(ctx, cr) {
+			return true
+		}
+	} else if s.wander(ctx, cr) {
+		return true
+	}
+
+	cas(ctx.X, ctx.Y, cr)
+	return true
+}
+
+// wander moves the shark to a uniformly random empty neighbor.
+func (s *sharkSpecies) wander(ctx *StepCtx, cr *creature) bool {
+	for i := 0; i < 4; i++ {
+		nx, ny := pickDirection(ctx, i)
+		if world[nx][ny] != nil {
+			continue
+		}
+		if !cas(nx, ny, cr) {
+			continue
+		}
+		if baby := s.Reproduce(cr); baby != nil {
+			cas(ctx.X, ctx.Y, baby)
+		}
+		return true
+	}
+	return false
+}
+
+// stepByScent samples the next square from a softmax over the scent value
+// of the four (empty) neighbors, favoring the strongest trail.
+func (s *sharkSpecies) stepByScent(ctx *StepCtx, cr *creature) bool {
+	north, south, east, west := adjacent(ctx.X, ctx.Y)
+	candidates := [4]coordinate{north, south, east, west}
+
+	var weights [4]float64
+	var total float64
+	any := false
+	for i, c := range candidates {
+		if world[c.x][c.y] != nil {
+			weights[i] = 0
+			continue
+		}
+		w := math.Exp(float64(scentField[c.x][c.y]) / s.temperature)
+		weights[i] = w
+		total += w
+		any = true
+	}
+	if !any || total == 0 {
+		return s.wander(ctx, cr)
+	}
+
+	pick := ctx.Rand.Float64() * total
+	chosen := candidates[0]
+	var cum float64
+	for i, c := range candidates {
+		if weights[i] == 0 {
+			continue
+		}
+		cum += weights[i]
+		if pick <= cum {
+			chosen = c
+			break
+		}
+	}
+
+	if !cas(chosen.x, chosen.y, cr) {
+		return s.wander(ctx, cr)
+	}
+	if baby := s.Reproduce(cr); baby != nil {
+		cas(ctx.X, ctx.Y, baby)
+	}
+	return true
+}
+
+func (s *sharkSpecies) Reproduce(cr *creature) *creature {
+	if cr.age > 0 && cr.age%*sBreed == 0 {
+		childEnergy := cr.health / 2
+		cr.health -= childEnergy
+		recordBirth(SHARK)
+		return &creature{age: 0, health: childEnergy, species: SHARK, asset: sharkcolor, chronon: cr.chronon}
+	}
+	return nil
+}
+
+func (s *sharkSpecies) OnDeath(cr *creature) { recordDeath(SHARK) }
@@ -0,0 +1,76 @@
+// This file gives the simulation a single root seed (-seed) from which every
+// source of randomness is derived deterministically, so that a run can be
+// resumed from a snapshot (see persist.go) and continue producing identical
+// chronons. Previously each updateSlice goroutine reseeded itself from
+// crypto/rand every chronon, and initWator seeded from wall-clock time, so
+// neither the initial layout nor any later chronon could be reproduced.
+package main
+
+import (
+	"flag"
+	"math/rand/v2"
+	"time"
+)
+
+// seedFlag is the master seed for the run. A value of 0 (the default) means
+// "pick a random seed at startup", preserving the historical behavior of a
+// fresh, non-reproducible layout every run; any nonzero value makes the run
+// fully deterministic.
+var seedFlag = flag.Int64("seed", 0, "Master seed for deterministic runs (0 picks a random seed at startup).")
+
+// rootSeed is the effective seed actually in use once main() has resolved
+// seedFlag==0 to a concrete random value, or loadSnapshot has restored it
+// from a saved run.
+var rootSeed uint64
+
+// resolveSeed sets rootSeed from seedFlag, drawing a fresh random seed when
+// the flag was left at its default of 0. It must be called once, after
+// flag.Parse, before initWator or any Chronon.
+func resolveSeed() {
+	if *seedFlag != 0 {
+		rootSeed = uint64(*seedFlag)
+		return
+	}
+	rootSeed = uint64(time.Now().UnixNano())
+}
+
+// splitmix64 mixes a 64-bit state forward one step, used below to turn
+// (rootSeed, chronon, stripe) tuples into well-distributed PCG seeds. It's
+// the standard SplitMix64 finalizer: small, unbiased, and cheap enough to
+// call once per goroutine per chronon.
+func splitmix64(x uint64) uint64 {
+	x += 0x9E3779B97F4A7C15
+	z := x
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// stripeRand returns the deterministic RNG stream for the goroutine updating
+// stripe `stripe` (its index among the goroutines Chronon launched this
+// chronon) during chronon `c`. The same (rootSeed, c, stripe) always yields
+// the same stream, so a resumed run reproduces its stripe boundaries exactly
+// as long as -threads is unchanged.
+func stripeRand(c, stripe int) *rand.Rand {
+	mixed := rootSeed ^ uint64(c)<<32 ^ uint64(stripe)
+	hi := splitmix64(mixed)
+	lo := splitmix64(hi)
+	return rand.New(rand.NewPCG(hi, lo))
+}
+
+// initRand returns the RNG stream used to place the initial population,
+// independent of any chronon or stripe.
+func initRand() *rand.Rand {
+	hi := splitmix64(rootSeed ^ 0x494E4954) // "INIT"
+	lo := splitmix64(hi)
+	return rand.New(rand.NewPCG(hi, lo))
+}
+
+// scheduleRand returns the RNG stream used to shuffle the whole-grid agent
+// list under -schedule=agentlist (see schedule.go), keyed by chronon so a
+// resumed run reshuffles identically.
+func scheduleRand(c int) *rand.Rand {
+	hi := splitmix64(rootSeed ^ uint64(c) ^ 0x5343484C) // "SCHL"
+	lo := splitmix64(hi)
+	return rand.New(rand.NewPCG(hi, lo))
+}
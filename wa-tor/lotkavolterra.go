@@ -0,0 +1,104 @@
+// This file fits the discrete Lotka-Volterra predator-prey parameters to the
+// chrononSeries a benchmark run collects (see statsseries.go), turning
+// -benchmark into a research tool rather than a pure timer: the classic use
+// of Wa-Tor, as described in the Scientific American article the original
+// FATFISH.PAS documents, is exploring how fish/shark breed and starve rates
+// trade off against the period and amplitude of the population cycle.
+package main
+
+import "math"
+
+// fitLotkaVolterra estimates alpha, beta, gamma, delta for the continuous
+// Lotka-Volterra model
+//
+//	dFish/dt  =  alpha*Fish  - beta*Fish*Shark
+//	dShark/dt = delta*Fish*Shark - gamma*Shark
+//
+// by linear regression of the discrete per-chronon growth rate
+// Δlog(N)/Δt against the other species' population:
+//
+//	Δlog(Fish)/Δt  =  alpha - beta*Shark   (slope -beta, intercept alpha)
+//	Δlog(Shark)/Δt =  delta*Fish - gamma   (slope delta, intercept -gamma)
+//
+// ok is false if series is too short, or too degenerate (e.g. one species
+// never present), to fit.
+func fitLotkaVolterra(series []chrononStats) (alpha, beta, gamma, delta float64, ok bool) {
+	if len(series) < 3 {
+		return 0, 0, 0, 0, false
+	}
+
+	var fishRate, fishShark, sharkRate, sharkFish []float64
+	for i := 1; i < len(series); i++ {
+		prev, cur := series[i-1], series[i]
+		if prev.Fish <= 0 || cur.Fish <= 0 || prev.Shark <= 0 || cur.Shark <= 0 {
+			continue // log undefined at zero population; skip this chronon.
+		}
+		fishRate = append(fishRate, math.Log(float64(cur.Fish))-math.Log(float64(prev.Fish)))
+		fishShark = append(fishShark, float64(prev.Shark))
+		sharkRate = append(sharkRate, math.Log(float64(cur.Shark))-math.Log(float64(prev.Shark)))
+		sharkFish = append(sharkFish, float64(prev.Fish))
+	}
+
+	if len(fishRate) < 2 {
+		return 0, 0, 0, 0, false
+	}
+
+	negBeta, a := linearRegression(fishShark, fishRate)
+	d, negGamma := linearRegression(sharkFish, sharkRate)
+
+	return a, -negBeta, -negGamma, d, true
+}
+
+// linearRegression fits y = slope*x + intercept by ordinary least squares.
+func linearRegression(xs, ys []float64) (slope, intercept float64) {
+	n := float64(len(xs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, sumY / n
+	}
+	slope = (n*sumXY - sumX*sumY) / denom
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept
+}
+
+// estimatePeriod returns the mean number of chronons between successive
+// upward crossings of the fish population's mean, i.e. the observed
+// oscillation period. ok is false if fewer than two crossings were found.
+func estimatePeriod(series []chrononStats) (period float64, ok bool) {
+	if len(series) < 3 {
+		return 0, false
+	}
+
+	var sum float64
+	for _, s := range series {
+		sum += float64(s.Fish)
+	}
+	mean := sum / float64(len(series))
+
+	var crossings []int
+	below := float64(series[0].Fish) < mean
+	for i := 1; i < len(series); i++ {
+		v := float64(series[i].Fish)
+		if below && v >= mean {
+			crossings = append(crossings, i)
+		}
+		below = v < mean
+	}
+
+	if len(crossings) < 2 {
+		return 0, false
+	}
+
+	gaps := 0.0
+	for i := 1; i < len(crossings); i++ {
+		gaps += float64(crossings[i] - crossings[i-1])
+	}
+	return gaps / float64(len(crossings)-1), true
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqual(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+// TestLinearRegressionExactFit asserts linearRegression recovers the exact
+// slope/intercept of a noiseless line, which fitLotkaVolterra relies on
+// when the discrete growth-rate series is clean.
+func TestLinearRegressionExactFit(t *testing.T) {
+	xs := []float64{0, 1, 2, 3, 4}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 2.5*x - 1.0
+	}
+
+	slope, intercept := linearRegression(xs, ys)
+	if !approxEqual(slope, 2.5, 1e-9) {
+		t.Fatalf("slope = %v, want 2.5", slope)
+	}
+	if !approxEqual(intercept, -1.0, 1e-9) {
+		t.Fatalf("intercept = %v, want -1.0", intercept)
+	}
+}
+
+// TestLinearRegressionConstantX asserts a degenerate (all-same-x) input
+// doesn't divide by zero: it should fall back to a zero slope and the mean
+// of ys, rather than panicking or returning NaN/Inf.
+func TestLinearRegressionConstantX(t *testing.T) {
+	xs := []float64{5, 5, 5}
+	ys := []float64{1, 2, 3}
+
+	slope, intercept := linearRegression(xs, ys)
+	if slope != 0 {
+		t.Fatalf("slope = %v, want 0", slope)
+	}
+	if !approxEqual(intercept, 2.0, 1e-9) {
+		t.Fatalf("intercept = %v, want 2.0 (mean of ys)", intercept)
+	}
+}
+
+// TestFitLotkaVolterraTooShort asserts a series with fewer than 3 points is
+// rejected rather than fit against too little data.
+func TestFitLotkaVolterraTooShort(t *testing.T) {
+	series := []chrononStats{{Fish: 10, Shark: 5}, {Fish: 11, Shark: 5}}
+	if _, _, _, _, ok := fitLotkaVolterra(series); ok {
+		t.Fatalf("fitLotkaVolterra(len=2) = ok, want rejected")
+	}
+}
+
+// TestFitLotkaVolterraRecoversKnownParameters generates a series that
+// exactly follows the discrete growth-rate equations fitLotkaVolterra
+// assumes, then asserts it recovers the parameters used to build it. The
+// populations stay in the low hundreds throughout (a gentle cycle around
+// the model's fixed point) so int-truncating each chronon's counts, as the
+// real simulation does, doesn't swamp the fit with rounding noise.
+func TestFitLotkaVolterraRecoversKnownParameters(t *testing.T) {
+	const (
+		alpha, beta, gamma, delta = 0.05, 0.0005, 0.05, 0.0005
+		fish0, shark0             = 120.0, 120.0
+		steps                     = 60
+	)
+
+	series := make([]chrononStats, steps)
+	fish, shark := fish0, shark0
+	for i := 0; i < steps; i++ {
+		series[i] = chrononStats{Fish: int(fish), Shark: int(shark)}
+		fishRate := alpha - beta*shark
+		sharkRate := delta*fish - gamma
+		fish *= math.Exp(fishRate)
+		shark *= math.Exp(sharkRate)
+	}
+
+	gotAlpha, gotBeta, gotGamma, gotDelta, ok := fitLotkaVolterra(series)
+	if !ok {
+		t.Fatalf("fitLotkaVolterra: ok = false, want true")
+	}
+	if !approxEqual(gotAlpha, alpha, 0.01) {
+		t.Fatalf("alpha = %v, want approximately %v", gotAlpha, alpha)
+	}
+	if !approxEqual(gotBeta, beta, 0.0001) {
+		t.Fatalf("beta = %v, want approximately %v", gotBeta, beta)
+	}
+	if !approxEqual(gotGamma, gamma, 0.01) {
+		t.Fatalf("gamma = %v, want approximately %v", gotGamma, gamma)
+	}
+	if !approxEqual(gotDelta, delta, 0.0001) {
+		t.Fatalf("delta = %v, want approximately %v", gotDelta, delta)
+	}
+}
+
+// TestEstimatePeriodFindsKnownPeriod builds a clean sinusoidal fish series
+// with a known period and asserts estimatePeriod recovers it.
+func TestEstimatePeriodFindsKnownPeriod(t *testing.T) {
+	const period = 10.0
+	series := make([]chrononStats, 41)
+	for i := range series {
+		fish := 100 + 50*math.Sin(2*math.Pi*float64(i)/period)
+		series[i] = chrononStats{Fish: int(fish)}
+	}
+
+	got, ok := estimatePeriod(series)
+	if !ok {
+		t.Fatalf("estimatePeriod: ok = false, want true")
+	}
+	if !approxEqual(got, period, 0.5) {
+		t.Fatalf("period = %v, want approximately %v", got, period)
+	}
+}
+
+// TestEstimatePeriodTooFewCrossings asserts a monotonic series (no
+// oscillation) is rejected rather than returning a meaningless period.
+func TestEstimatePeriodTooFewCrossings(t *testing.T) {
+	series := make([]chrononStats, 10)
+	for i := range series {
+		series[i] = chrononStats{Fish: int(100 + i)}
+	}
+	if _, ok := estimatePeriod(series); ok {
+		t.Fatalf("estimatePeriod on a monotonic series: ok = true, want false")
+	}
+}
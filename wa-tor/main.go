@@ -11,19 +11,15 @@
 package main
 
 import (
-	crand "crypto/rand"
-	"encoding/binary"
 	"flag"
 	"fmt"
 	"image/color"
 	"log"
-	"math/rand"
+	"math/rand/v2"
 	"runtime"
 	"strconv"
 	"sync"
-	"sync/atomic"
 	"time"
-	"unsafe"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -73,6 +69,10 @@ var (
 
 	// chronons is the total number of time steps to run in benchmark mode.
 	chronons = flag.Int("chronons", 2000, "Number of chronons to run in benchmark mode.")
+
+	// scale is the integer pixel scale the GUI renders the grid at; the
+	// simulation itself always runs at wwidth x wheight.
+	scale = flag.Int("scale", 1, "Integer pixel scale to render the GUI window at.")
 )
 
 // tick is the global tick counter for the simulation.
@@ -84,7 +84,10 @@ var world [][]*creature
 // nextWorld is the double-buffered grid storage for the next state.
 var nextWorld [][]*creature
 
-// Creature Species Constants.
+// Creature Species Constants. These are just the ids the two default
+// Species registrations use (see registerDefaultSpecies in species.go); a
+// -species-config file can register further ids for additional trophic
+// levels.
 const (
 	FISH = iota
 	SHARK
@@ -101,50 +104,101 @@ var (
 type creature struct {
 	age     int        // Current age of the creature in chronons.
 	health  int        // Energy level (relevant for Sharks).
-	species int        // Species type: FISH or SHARK.
+	species int        // Species id; looked up in speciesRegistry (see species.go).
 	asset   color.RGBA // Color representation for the GUI.
 	chronon int        // Last chronon this creature was updated (to prevent double moves).
 }
 
 // Chronon executes a single step (time step) of the simulation.
 //
-// This function divides the grid into horizontal strips and assigns them to
-// parallel worker goroutines based on the configured thread count. It waits
-// for all workers to finish before swapping the grid buffers.
+// It dispatches all live creatures to parallel worker goroutines under one
+// of two schedulers (see -schedule, schedule.go): "rowstrip", the original
+// top-to-bottom horizontal strips, or "agentlist", a shuffled flat list of
+// every live coordinate split into *nThreads chunks, which removes the
+// low-x/low-y movement bias rowstrip gives creatures that get processed
+// first. Either way it waits for every worker to finish, diffuses the scent
+// field (if enabled) over the same row stripes, and swaps the grid buffers.
+//
+// In -benchmark mode, each worker also reports a chrononStats tally of the
+// births and deaths it caused through statsCh; once every worker has
+// finished (wg.Wait), Chronon sums those with a full-grid population tally
+// (tallyPopulation) into one row, which recordChrononStats appends to
+// chrononSeries and (if -stats-out is set) writes out as CSV (see
+// statsseries.go). The population tally runs only after the barrier, and
+// scans nextWorld's full coordinate space rather than any one worker's
+// dispatch range, so it is correct regardless of scheduler (a creature can
+// move to a cell outside its own stripe/chunk) and never races a concurrent
+// CAS into a cell it's reading.
 //
 // c is the current chronon index.
 func Chronon(c int) {
 	var wg sync.WaitGroup
 
 	numGoroutines := *nThreads
-
 	if numGoroutines <= 0 {
 		numGoroutines = 1
 	}
-
 	if numGoroutines > *wheight {
 		numGoroutines = *wheight
 	}
 
-	rowsPerGoroutine := *wheight / numGoroutines
-
-	// Launch worker threads to update slices of the grid.
-	for i := 0; i < numGoroutines; i++ {
-		startY := i * rowsPerGoroutine
-		endY := startY + rowsPerGoroutine
+	var statsCh chan chrononStats
+	if *benchmark {
+		statsCh = make(chan chrononStats, numGoroutines)
+	}
 
-		if i == numGoroutines-1 {
-			endY = *wheight
+	// dispatched is how many workers were actually started this chronon, so
+	// the statsCh receive loop below waits for exactly that many sends.
+	// chunkAgents clamps its chunk count down to len(agents) when the live
+	// population is smaller than numGoroutines, so agentlist mode can start
+	// fewer workers than numGoroutines; rowstrip always starts exactly
+	// numGoroutines.
+	dispatched := numGoroutines
+
+	if *scheduleMode == "agentlist" {
+		agents := liveAgents()
+		scheduleRand(c).Shuffle(len(agents), func(i, j int) {
+			agents[i], agents[j] = agents[j], agents[i]
+		})
+		chunks := chunkAgents(agents, numGoroutines)
+		dispatched = len(chunks)
+		for i, chunk := range chunks {
+			wg.Add(1)
+			go updateAgents(c, i, chunk, &wg, statsCh)
+		}
+	} else {
+		rowsPerGoroutine := *wheight / numGoroutines
+		for i := 0; i < numGoroutines; i++ {
+			startY := i * rowsPerGoroutine
+			endY := startY + rowsPerGoroutine
+			if i == numGoroutines-1 {
+				endY = *wheight
+			}
+			wg.Add(1)
+			go updateSlice(c, i, startY, endY, &wg, statsCh)
 		}
-
-		wg.Add(1)
-		go updateSlice(c, startY, endY, &wg)
 	}
 
 	wg.Wait()
 
+	if statsCh != nil {
+		var total chrononStats
+		for i := 0; i < dispatched; i++ {
+			total.add(<-statsCh)
+		}
+		total.add(tallyPopulation(numGoroutines))
+		recordChrononStats(c, total)
+	}
+
+	if scentField != nil {
+		diffuseScent(numGoroutines)
+	}
+
 	// Swap double buffers.
 	world, nextWorld = nextWorld, world
+	if scentField != nil {
+		scentField, nextScentField = nextScentField, scentField
+	}
 
 	for i := range nextWorld {
 		for j := range nextWorld[i] {
@@ -153,155 +207,138 @@ func Chronon(c int) {
 	}
 }
 
-// updateSlice updates a specific horizontal slice of the world grid.
-//
-// It handles the movement, feeding, and reproduction logic for all creatures
-// within the specified Y-coordinate range (startY inclusive, endY exclusive).
-// It uses atomic operations to safely write to the nextWorld grid.
-func updateSlice(c, startY, endY int, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	// Initialize a unique random seed for this goroutine.
-	var seed int64
-	var b [8]byte
-	_, err := crand.Read(b[:])
-	if err != nil {
-		seed = time.Now().UnixNano() + int64(startY)
-	} else {
-		seed = int64(binary.LittleEndian.Uint64(b[:]))
+// tallyCell adds the FISH/SHARK population at nextWorld[x][y], if any, to
+// stats.
+func tallyCell(x, y int, stats *chrononStats) {
+	cr := nextWorld[x][y]
+	if cr == nil {
+		return
 	}
-	r := rand.New(rand.NewSource(seed))
-
-	var newX, newY int
+	switch cr.species {
+	case FISH:
+		stats.Fish++
+	case SHARK:
+		stats.Shark++
+		stats.SharkHealthSum += int64(cr.health)
+	}
+}
 
-	for y := startY; y < endY; y++ {
-		for x := 0; x < *wwidth; x++ {
+// tallyPopulation counts the full nextWorld grid's FISH/SHARK population,
+// split into numGoroutines row stripes the same way diffuseScent is, and
+// must only be called after Chronon's wg.Wait() — i.e. once every mover and
+// newborn has already been written into nextWorld, so there is no
+// concurrent CAS left for this read-only pass to race.
+func tallyPopulation(numGoroutines int) chrononStats {
+	if numGoroutines <= 0 {
+		numGoroutines = 1
+	}
+	if numGoroutines > *wheight {
+		numGoroutines = *wheight
+	}
 
-			if world[x][y] == nil {
-				continue
+	rowsPerWorker := *wheight / numGoroutines
+	partials := make([]chrononStats, numGoroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < numGoroutines; i++ {
+		startY := i * rowsPerWorker
+		endY := startY + rowsPerWorker
+		if i == numGoroutines-1 {
+			endY = *wheight
+		}
+		wg.Add(1)
+		go func(i, startY, endY int) {
+			defer wg.Done()
+			for y := startY; y < endY; y++ {
+				for x := 0; x < *wwidth; x++ {
+					tallyCell(x, y, &partials[i])
+				}
 			}
+		}(i, startY, endY)
+	}
+	wg.Wait()
 
-			// Copy creature data to avoid read conflicts.
-			cr := *world[x][y]
-			cr.age++
-			cr.chronon = c
-
-			moved := false
-
-			switch cr.species {
-			case FISH:
-				// Fish behavior: Move randomly to an empty adjacent spot.
-				for i := 0; i < 4; i++ {
-					north, south, east, west := adjacent(x, y)
-					d := r.Intn(4)
-					switch (d + i) % 4 {
-					case NORTH:
-						newX, newY = north.x, north.y
-					case SOUTH:
-						newX, newY = south.x, south.y
-					case EAST:
-						newX, newY = east.x, east.y
-					case WEST:
-						newX, newY = west.x, west.y
-					}
+	var total chrononStats
+	for _, p := range partials {
+		total.add(p)
+	}
+	return total
+}
 
-					if world[newX][newY] == nil {
-						// Use atomic CAS to claim the spot in the next world state.
-						if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[newX][newY])), nil, unsafe.Pointer(&cr)) {
-							moved = true
-							// Reproduce if old enough.
-							if cr.age > 0 && cr.age%*fBreed == 0 {
-								babyFish := &creature{age: 0, species: FISH, asset: fishcolor, chronon: c}
-								atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(babyFish))
-							}
-							break
-						}
-					}
-				}
+// updateSlice updates every creature in horizontal rows [startY, endY) under
+// the "rowstrip" scheduler.
+//
+// stripe identifies this goroutine's position among the numGoroutines
+// launched this chronon by Chronon; combined with the root seed and c, it
+// derives this call's RNG stream deterministically (see determinism.go)
+// instead of reading crypto/rand, so a run can be resumed from a snapshot
+// (see persist.go) and continue producing identical chronons. If statsCh is
+// non-nil (benchmark mode), this stripe's birth/death chrononStats tally is
+// sent on it before returning; population counts are tallied separately by
+// tallyPopulation, after every worker has finished.
+func updateSlice(c, stripe, startY, endY int, wg *sync.WaitGroup, statsCh chan<- chrononStats) {
+	defer wg.Done()
+	r := stripeRand(c, stripe)
 
-				if !moved {
-					atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(&cr))
-				}
+	var stats *chrononStats
+	if statsCh != nil {
+		stats = &chrononStats{}
+	}
 
-			case SHARK:
-				// Shark behavior: Starve if health is depleted.
-				cr.health--
-				if cr.health <= 0 {
-					continue
-				}
+	for y := startY; y < endY; y++ {
+		for x := 0; x < *wwidth; x++ {
+			stepCreature(c, x, y, r, stats)
+		}
+	}
 
-				// Priority 1: Hunt for adjacent fish.
-				for i := 0; i < 4; i++ {
-					north, south, east, west := adjacent(x, y)
-					d := r.Intn(4)
-					switch (d + i) % 4 {
-					case NORTH:
-						newX, newY = north.x, north.y
-					case SOUTH:
-						newX, newY = south.x, south.y
-					case EAST:
-						newX, newY = east.x, east.y
-					case WEST:
-						newX, newY = west.x, west.y
-					}
+	if statsCh != nil {
+		statsCh <- *stats
+	}
+}
 
-					if world[newX][newY] != nil && world[newX][newY].species == FISH {
-						cr.health = *starve
-						if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[newX][newY])), nil, unsafe.Pointer(&cr)) {
-							moved = true
-							// Reproduce if old enough (split energy).
-							if cr.age > 0 && cr.age%*sBreed == 0 {
-								childEnergy := cr.health / 2
-								cr.health -= childEnergy
-
-								babyShark := &creature{age: 0, health: childEnergy, species: SHARK, asset: sharkcolor, chronon: c}
-								atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(babyShark))
-							}
-							break
-						}
-					}
-				}
+// updateAgents updates exactly the creatures at the given coordinates under
+// the "agentlist" scheduler; coords is one of the *nThreads chunks Chronon
+// split the shuffled, whole-grid agent list into, so which goroutine handles
+// which coordinate no longer depends on grid position. stripe is this
+// chunk's index, used the same way as in updateSlice to derive a
+// deterministic RNG stream. If statsCh is non-nil (benchmark mode), this
+// chunk's birth/death chrononStats tally is sent on it before returning;
+// population counts are tallied separately by tallyPopulation, after every
+// worker has finished (coords are creatures' pre-move coordinates, so a
+// moved creature's new cell is not necessarily in this, or any, chunk).
+func updateAgents(c, stripe int, coords []coordinate, wg *sync.WaitGroup, statsCh chan<- chrononStats) {
+	defer wg.Done()
+	r := stripeRand(c, stripe)
 
-				if moved {
-					continue
-				}
+	var stats *chrononStats
+	if statsCh != nil {
+		stats = &chrononStats{}
+	}
 
-				// Priority 2: Move to an empty adjacent square if no fish found.
-				for i := 0; i < 4; i++ {
-					north, south, east, west := adjacent(x, y)
-					d := r.Intn(4)
-					switch (d + i) % 4 {
-					case NORTH:
-						newX, newY = north.x, north.y
-					case SOUTH:
-						newX, newY = south.x, south.y
-					case EAST:
-						newX, newY = east.x, east.y
-					case WEST:
-						newX, newY = west.x, west.y
-					}
+	for _, p := range coords {
+		stepCreature(c, p.x, p.y, r, stats)
+	}
 
-					if world[newX][newY] == nil {
-						if atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[newX][newY])), nil, unsafe.Pointer(&cr)) {
-							moved = true
-							if cr.age > 0 && cr.age%*sBreed == 0 {
-								childEnergy := cr.health / 2
-								cr.health -= childEnergy
-
-								babyShark := &creature{age: 0, health: childEnergy, species: SHARK, asset: sharkcolor, chronon: c}
-								atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(babyShark))
-							}
-							break
-						}
-					}
-				}
+	if statsCh != nil {
+		statsCh <- *stats
+	}
+}
 
-				if !moved {
-					atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&nextWorld[x][y])), nil, unsafe.Pointer(&cr))
-				}
-			}
-		}
+// stepCreature runs one chronon of movement, feeding, and reproduction logic
+// for the creature at (x, y), if any, by dispatching to its registered
+// Species (see species.go). It is the shared body behind both updateSlice
+// and updateAgents, and the only place that still knows about world,
+// nextWorld, and the per-goroutine RNG stream — everything species-specific
+// lives behind the Species interface instead of a switch here. stats is nil
+// unless -benchmark is collecting a time series (see statsseries.go).
+func stepCreature(c, x, y int, r *rand.Rand, stats *chrononStats) {
+	if world[x][y] == nil {
+		return
 	}
+	sp := speciesFor(world[x][y].species)
+	if sp == nil {
+		return // no Species registered for this id; nothing to do.
+	}
+	sp.Step(&StepCtx{X: x, Y: y, Chronon: c, World: world, NextWorld: nextWorld, Rand: r, Stats: stats})
 }
 
 // adjacent calculates adjacent coordinates wrapping around the toroidal world.
@@ -339,9 +376,15 @@ func adjacent(x, y int) (coordinate, coordinate, coordinate, coordinate) {
 // initWator initializes the simulation world.
 //
 // It allocates memory for the grid and randomly populates it with the specified
-// number of fish and sharks. It returns two grids: the initial world state and
-// the empty 'next' state buffer.
+// number of fish and sharks, plus any -species-config entries that declared a
+// population (see configuredPopulations in speciesconfig.go). It returns two
+// grids: the initial world state and the empty 'next' state buffer.
+//
+// Placement is drawn from initRand(), the stream derived from the root seed
+// (see determinism.go), so the starting layout for a given -seed is
+// identical across runs.
 func initWator() ([][]*creature, [][]*creature) {
+	initScent()
 
 	var wm = make([][]*creature, *wwidth)
 	for i := range wm {
@@ -353,19 +396,19 @@ func initWator() ([][]*creature, [][]*creature) {
 	}
 
 	pop := 0
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := initRand()
 
 	for i := 0; i < *nFish; i++ {
 		for {
 			if pop == *wwidth**wheight {
 				break
 			}
-			x := r.Intn(*wwidth - 1)
-			y := r.Intn(*wheight - 1)
+			x := r.IntN(*wwidth - 1)
+			y := r.IntN(*wheight - 1)
 
 			if wm[x][y] == nil {
 				wm[x][y] = &creature{
-					age:     rand.Intn(*fBreed),
+					age:     r.IntN(*fBreed),
 					species: FISH,
 					asset:   fishcolor,
 				}
@@ -380,12 +423,12 @@ func initWator() ([][]*creature, [][]*creature) {
 			if pop == *wwidth**wheight {
 				break
 			}
-			x := r.Intn(*wwidth - 1)
-			y := r.Intn(*wheight - 1)
+			x := r.IntN(*wwidth - 1)
+			y := r.IntN(*wheight - 1)
 
 			if wm[x][y] == nil {
 				wm[x][y] = &creature{
-					age:     rand.Intn(*sBreed),
+					age:     r.IntN(*sBreed),
 					species: SHARK,
 					health:  *starve,
 					asset:   sharkcolor,
@@ -396,6 +439,39 @@ func initWator() ([][]*creature, [][]*creature) {
 		}
 	}
 
+	for _, id := range configuredSpeciesIDsInOrder() {
+		gs := speciesFor(id).(*genericSpecies)
+		breed := gs.breed
+		if breed <= 0 {
+			breed = 1
+		}
+		health := 0
+		if gs.hasHealth {
+			health = gs.starve
+		}
+
+		for i := 0; i < configuredPopulations[id]; i++ {
+			for {
+				if pop == *wwidth**wheight {
+					break
+				}
+				x := r.IntN(*wwidth - 1)
+				y := r.IntN(*wheight - 1)
+
+				if wm[x][y] == nil {
+					wm[x][y] = &creature{
+						age:     r.IntN(breed),
+						species: id,
+						health:  health,
+						asset:   gs.Color(),
+					}
+					pop++
+					break
+				}
+			}
+		}
+	}
+
 	return wm, nwm
 }
 
@@ -419,34 +495,115 @@ func debug() {
 }
 
 // Game is a struct implementing the Ebiten interface.
-type Game struct{}
+//
+// img and buf are a persistent GPU-backed image and its CPU-side pixel
+// buffer, lazily allocated by the first Draw call and reused every frame
+// thereafter; this replaces the previous per-pixel screen.Set calls, which
+// were the dominant per-frame cost at grid sizes like 900x600.
+type Game struct {
+	img *ebiten.Image
+	buf []byte
+}
 
 // Update updates the game state. It is called every frame (tick).
 func (g *Game) Update() error {
 	tick++
 	Chronon(tick)
+	if *savePath != "" && *snapshotEvery > 0 && tick%*snapshotEvery == 0 {
+		saveSnapshot(*savePath, tick)
+	}
 	return nil
 }
 
-// Draw draws the current game state to the screen.
+// Draw draws the current game state to the screen. It fills g.buf with the
+// grid's RGBA pixels (in parallel, using the same row-stripe partitioning as
+// Chronon), uploads it to the GPU in one call via img.WritePixels, and draws
+// the result scaled up by -scale.
 func (g *Game) Draw(screen *ebiten.Image) {
-	screen.Fill(watercolor)
-	for x := 0; x < *wwidth; x++ {
-		for y := 0; y < *wheight; y++ {
-			if world[x][y] != nil {
-				screen.Set(x, y, world[x][y].asset)
-			} else {
-				screen.Set(x, y, watercolor)
-			}
-		}
+	if g.img == nil {
+		g.img = ebiten.NewImage(*wwidth, *wheight)
+		g.buf = make([]byte, 4**wwidth**wheight)
 	}
+
+	fillPixelBuffer(g.buf)
+	g.img.WritePixels(g.buf)
+
+	var op ebiten.DrawImageOptions
+	op.GeoM.Scale(float64(*scale), float64(*scale))
+	screen.DrawImage(g.img, &op)
+
 	ebitenutil.DebugPrint(screen, strconv.Itoa(tick))
 }
 
+// fillPixelBuffer writes the grid's current RGBA pixels into buf (laid out
+// row-major, 4 bytes per pixel, matching the format *ebiten.Image.WritePixels
+// expects), split into *nThreads row stripes the same way Chronon splits
+// work under the "rowstrip" scheduler.
+func fillPixelBuffer(buf []byte) {
+	renderScent := *scentMode == "render" && scentField != nil
+
+	numGoroutines := *nThreads
+	if numGoroutines <= 0 {
+		numGoroutines = 1
+	}
+	if numGoroutines > *wheight {
+		numGoroutines = *wheight
+	}
+
+	rowsPerGoroutine := *wheight / numGoroutines
+	var wg sync.WaitGroup
+	for i := 0; i < numGoroutines; i++ {
+		startY := i * rowsPerGoroutine
+		endY := startY + rowsPerGoroutine
+		if i == numGoroutines-1 {
+			endY = *wheight
+		}
+		wg.Add(1)
+		go func(startY, endY int) {
+			defer wg.Done()
+			for y := startY; y < endY; y++ {
+				for x := 0; x < *wwidth; x++ {
+					var c color.RGBA
+					switch {
+					case world[x][y] != nil:
+						c = world[x][y].asset
+					case renderScent:
+						c = scentUnderlay(x, y)
+					default:
+						c = watercolor
+					}
+					idx := (y**wwidth + x) * 4
+					buf[idx] = c.R
+					buf[idx+1] = c.G
+					buf[idx+2] = c.B
+					buf[idx+3] = c.A
+				}
+			}
+		}(startY, endY)
+	}
+	wg.Wait()
+}
+
+// scentUnderlayCap is the scent value that maps to full blue intensity; scent
+// accumulates without an upper bound, so this is just a display scale.
+const scentUnderlayCap = 8.0
+
+// scentUnderlay renders an empty cell's scent value as a blue channel
+// underlay: watercolor with blue intensity proportional to local scent,
+// clamped at scentUnderlayCap.
+func scentUnderlay(x, y int) color.RGBA {
+	v := float64(scentField[x][y]) / scentUnderlayCap
+	if v > 1 {
+		v = 1
+	}
+	return color.RGBA{watercolor.R, watercolor.G, uint8(v * 255), 0}
+}
+
 // Layout defines the screen layout.
-// It returns the internal logical screen dimensions.
+// It returns the logical screen dimensions, scaled by -scale; the
+// simulation grid itself is still only wwidth x wheight cells.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return *wwidth, *wheight
+	return *wwidth * *scale, *wheight * *scale
 }
 
 // main is the entry point.
@@ -455,37 +612,73 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 // or the graphical loop.
 func main() {
 	flag.Parse()
+	resolveSeed()
+	registerDefaultSpecies()
+	if *speciesConfigPath != "" {
+		if err := loadSpeciesConfig(*speciesConfigPath); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	if *nFish+*nSharks > *wwidth**wheight {
+	// A -load file brings its own dimensions and population, so the space
+	// check only applies when starting from a fresh random layout.
+	if *loadPath == "" && *nFish+*nSharks > *wwidth**wheight {
 		log.Fatal("Not enough space for Fish and Shark!")
 	}
 
 	// Set process limits for accurate benchmarking and parallel execution.
 	runtime.GOMAXPROCS(*nThreads)
 
+	loadWorld := func() ([][]*creature, [][]*creature) {
+		if *loadPath != "" {
+			return loadSnapshot(*loadPath)
+		}
+		return initWator()
+	}
+
 	if *benchmark {
 		// Headless benchmark mode.
 		fmt.Printf("Running Wa-Tor benchmark...\n")
-		fmt.Printf("Config: Threads=%d, Chronons=%d, Width=%d, Height=%d, Fish=%d, Sharks=%d\n",
-			*nThreads, *chronons, *wwidth, *wheight, *nFish, *nSharks)
+		fmt.Printf("Config: Seed=%d, Threads=%d, Chronons=%d, Width=%d, Height=%d, Fish=%d, Sharks=%d\n",
+			rootSeed, *nThreads, *chronons, *wwidth, *wheight, *nFish, *nSharks)
 
-		world, nextWorld = initWator()
+		world, nextWorld = loadWorld()
+		openStatsCSV()
 
 		startTime := time.Now()
 
-		for i := 0; i < *chronons; i++ {
+		start := tick
+		for i := start; i < start+*chronons; i++ {
 			Chronon(i)
+			tick = i + 1
+			if *savePath != "" && *snapshotEvery > 0 && tick%*snapshotEvery == 0 {
+				saveSnapshot(*savePath, tick)
+			}
 		}
 
 		duration := time.Since(startTime)
+		closeStatsCSV()
 
 		fmt.Printf("--- Benchmark Complete ---\n")
 		fmt.Printf("Total time for %d chronons with %d threads: %v\n", *chronons, *nThreads, duration)
+		fmt.Printf("Mean lifetime (schedule=%s): fish=%.2f shark=%.2f chronons\n",
+			*scheduleMode, meanFishLifetime(), meanSharkLifetime())
+
+		if alpha, beta, gamma, delta, ok := fitLotkaVolterra(chrononSeries); ok {
+			fmt.Printf("Lotka-Volterra fit: alpha=%.5f beta=%.6f gamma=%.5f delta=%.6f\n", alpha, beta, gamma, delta)
+		}
+		if period, ok := estimatePeriod(chrononSeries); ok {
+			fmt.Printf("Observed oscillation period: %.1f chronons\n", period)
+		}
+
+		if *savePath != "" {
+			saveSnapshot(*savePath, tick)
+		}
 
 	} else {
 		// Interactive Ebiten GUI mode.
-		world, nextWorld = initWator()
-		ebiten.SetWindowSize(900, 600)
+		world, nextWorld = loadWorld()
+		ebiten.SetWindowSize(*wwidth**scale, *wheight**scale)
 		ebiten.SetWindowTitle("Wator")
 
 		if err := ebiten.RunGame(&Game{}); err != nil {
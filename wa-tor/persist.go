@@ -0,0 +1,157 @@
+// This file adds -save/-load/-snapshot-every so an interesting equilibrium
+// seen in the Ebiten window (or reached mid-benchmark) can be captured to a
+// file and resumed bit-for-bit later. Since updateSlice's per-stripe RNG
+// streams (see determinism.go) are a pure function of -seed, the chronon,
+// and the stripe index, the snapshot only needs to carry rootSeed and tick
+// rather than every goroutine's live RNG state: resuming just re-derives the
+// same streams going forward.
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"flag"
+	"io"
+	"log"
+	"os"
+)
+
+// Persistence configuration flags.
+var (
+	// savePath, if set, writes a snapshot to this path after the run (benchmark mode) or every -snapshot-every chronons (GUI mode).
+	savePath = flag.String("save", "", "Path to write a simulation snapshot to; empty disables saving.")
+
+	// loadPath, if set, resumes the simulation from this snapshot instead of a fresh random population.
+	loadPath = flag.String("load", "", "Path to a simulation snapshot to resume from; empty starts a fresh random population.")
+
+	// snapshotEvery, if nonzero, writes a -save snapshot every N chronons in addition to any end-of-run save.
+	snapshotEvery = flag.Int("snapshot-every", 0, "Write a snapshot every N chronons when -save is set (0 disables periodic snapshots).")
+)
+
+// snapMagic identifies a Wa-Tor snapshot file; snapVersion lets the format
+// evolve without breaking old saves.
+const (
+	snapMagic   = "WSNAP"
+	snapVersion = 1
+)
+
+// saveSnapshot writes the full simulation state needed to resume bit-for-bit:
+// grid dimensions, breed/starve parameters, the master seed, the current
+// chronon, and every living creature's age/health/species/chronon. The
+// -scent field is not saved: loadSnapshot starts it at zero, so a run with
+// -scent enabled does not resume bit-for-bit.
+func saveSnapshot(path string, c int) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Printf("snapshot: creating %s: %v", path, err)
+		return
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	io.WriteString(w, snapMagic)
+	binary.Write(w, binary.LittleEndian, uint32(snapVersion))
+	binary.Write(w, binary.LittleEndian, uint32(*wwidth))
+	binary.Write(w, binary.LittleEndian, uint32(*wheight))
+	binary.Write(w, binary.LittleEndian, uint32(*fBreed))
+	binary.Write(w, binary.LittleEndian, uint32(*sBreed))
+	binary.Write(w, binary.LittleEndian, uint32(*starve))
+	binary.Write(w, binary.LittleEndian, rootSeed)
+	binary.Write(w, binary.LittleEndian, uint32(c))
+
+	var cells []sceneCreature
+	for x := 0; x < *wwidth; x++ {
+		for y := 0; y < *wheight; y++ {
+			if cr := world[x][y]; cr != nil {
+				cells = append(cells, sceneCreature{X: x, Y: y, Species: cr.species, Age: cr.age, Health: cr.health, Chronon: cr.chronon})
+			}
+		}
+	}
+
+	binary.Write(w, binary.LittleEndian, uint32(len(cells)))
+	for _, cell := range cells {
+		binary.Write(w, binary.LittleEndian, uint32(cell.X))
+		binary.Write(w, binary.LittleEndian, uint32(cell.Y))
+		binary.Write(w, binary.LittleEndian, uint32(cell.Species))
+		binary.Write(w, binary.LittleEndian, uint32(cell.Age))
+		binary.Write(w, binary.LittleEndian, uint32(cell.Health))
+		binary.Write(w, binary.LittleEndian, uint32(cell.Chronon))
+	}
+
+	if err := w.Flush(); err != nil {
+		log.Printf("snapshot: writing %s: %v", path, err)
+	}
+}
+
+// sceneCreature is the on-disk representation of a single creature in a
+// snapshot file.
+type sceneCreature struct {
+	X, Y, Species, Age, Health, Chronon int
+}
+
+// loadSnapshot reads a snapshot written by saveSnapshot, restoring
+// rootSeed and tick as package globals and returning the world/nextWorld
+// grids it describes. Grid dimensions and breed/starve parameters in the
+// file override whatever -width/-height/-fbreed/-sbreed/-starve were passed,
+// since resuming only makes sense against the configuration the snapshot was
+// taken under.
+func loadSnapshot(path string) ([][]*creature, [][]*creature) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("snapshot: opening %s: %v", path, err)
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	magic := make([]byte, len(snapMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != snapMagic {
+		log.Fatalf("snapshot: %s is not a Wa-Tor snapshot", path)
+	}
+	var version, w, h, fb, sb, st uint32
+	var seed uint64
+	var c uint32
+	binary.Read(r, binary.LittleEndian, &version)
+	binary.Read(r, binary.LittleEndian, &w)
+	binary.Read(r, binary.LittleEndian, &h)
+	binary.Read(r, binary.LittleEndian, &fb)
+	binary.Read(r, binary.LittleEndian, &sb)
+	binary.Read(r, binary.LittleEndian, &st)
+	binary.Read(r, binary.LittleEndian, &seed)
+	binary.Read(r, binary.LittleEndian, &c)
+
+	*wwidth, *wheight = int(w), int(h)
+	*fBreed, *sBreed, *starve = int(fb), int(sb), int(st)
+	rootSeed = seed
+	tick = int(c)
+	initScent()
+
+	wm := make([][]*creature, *wwidth)
+	nwm := make([][]*creature, *wwidth)
+	for i := range wm {
+		wm[i] = make([]*creature, *wheight)
+		nwm[i] = make([]*creature, *wheight)
+	}
+
+	var count uint32
+	binary.Read(r, binary.LittleEndian, &count)
+	for i := uint32(0); i < count; i++ {
+		var x, y, species, age, health, chronon uint32
+		binary.Read(r, binary.LittleEndian, &x)
+		binary.Read(r, binary.LittleEndian, &y)
+		binary.Read(r, binary.LittleEndian, &species)
+		binary.Read(r, binary.LittleEndian, &age)
+		binary.Read(r, binary.LittleEndian, &health)
+		binary.Read(r, binary.LittleEndian, &chronon)
+
+		if int(x) >= *wwidth || int(y) >= *wheight {
+			log.Fatalf("snapshot: %s: creature at (%d, %d) is out of bounds for a %dx%d world", path, x, y, *wwidth, *wheight)
+		}
+		sp := speciesFor(int(species))
+		if sp == nil {
+			log.Fatalf("snapshot: %s: creature at (%d, %d) has unrecognized species %d", path, x, y, species)
+		}
+		wm[x][y] = &creature{age: int(age), health: int(health), species: int(species), asset: sp.Color(), chronon: int(chronon)}
+	}
+
+	return wm, nwm
+}
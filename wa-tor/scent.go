@@ -0,0 +1,140 @@
+// This file adds an ant-colony-style "fish scent" field: fish deposit scent
+// into their own cell each chronon, and a diffusion+evaporation pass spreads
+// and decays it across the grid, giving sharks with no adjacent fish a
+// gradient to climb instead of wandering uniformly at random. It's gated
+// behind -scent so the classical random-wander shark is still the default.
+package main
+
+import (
+	"flag"
+	"math/rand/v2"
+	"sync"
+)
+
+// Scent configuration flags.
+var (
+	// scentMode selects scent tracking: "off" disables it (classical random
+	// wander), "render" enables it and additionally blends it into Game.Draw
+	// as a blue channel underlay.
+	scentMode = flag.String("scent", "off", "Fish scent field: off|render.")
+
+	// scentAlpha is the weight a cell's own scent keeps each chronon.
+	scentAlpha = flag.Float64("scent-alpha", 0.6, "Weight of a cell's own scent in the diffusion pass.")
+
+	// scentBeta is the weight given to the average of a cell's four neighbors.
+	scentBeta = flag.Float64("scent-beta", 0.4, "Weight of the neighbor average in the diffusion pass.")
+
+	// scentEvap is the fraction of scent lost to evaporation each chronon.
+	scentEvap = flag.Float64("scent-evap", 0.05, "Fraction of scent that evaporates each chronon.")
+
+	// scentDeposit is how much scent a fish adds to its own cell each chronon.
+	scentDeposit = flag.Float64("scent-deposit", 1.0, "Amount of scent a fish deposits into its cell each chronon.")
+)
+
+// scentEnabled reports whether -scent is anything other than "off".
+func scentEnabled() bool {
+	return *scentMode != "off"
+}
+
+// scentField is the current scent grid, same shape as world; nil means
+// scent tracking is disabled.
+var scentField [][]float32
+
+// nextScentField is the write buffer for the diffusion pass, swapped with
+// scentField alongside world/nextWorld in Chronon.
+var nextScentField [][]float32
+
+// initScent allocates the scent double buffer when -scent is enabled.
+// Called from initWator and loadSnapshot.
+func initScent() {
+	if !scentEnabled() {
+		return
+	}
+	scentField = make([][]float32, *wwidth)
+	nextScentField = make([][]float32, *wwidth)
+	for i := range scentField {
+		scentField[i] = make([]float32, *wheight)
+		nextScentField[i] = make([]float32, *wheight)
+	}
+}
+
+// depositScent records that a fish currently occupies (x, y); called from
+// updateSlice while processing a FISH creature.
+func depositScent(x, y int) {
+	if scentField == nil {
+		return
+	}
+	scentField[x][y] += float32(*scentDeposit)
+}
+
+// diffuseScent runs diffuseScentSlice across the full grid, split into
+// numWorkers row stripes, as its own phase after every creature has moved.
+// It always partitions by row regardless of -schedule, since diffusion is a
+// separate pass over the whole field and isn't tied to how creature updates
+// were scheduled.
+func diffuseScent(numWorkers int) {
+	if numWorkers <= 0 {
+		numWorkers = 1
+	}
+	if numWorkers > *wheight {
+		numWorkers = *wheight
+	}
+
+	rowsPerWorker := *wheight / numWorkers
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		startY := i * rowsPerWorker
+		endY := startY + rowsPerWorker
+		if i == numWorkers-1 {
+			endY = *wheight
+		}
+		wg.Add(1)
+		go func(startY, endY int) {
+			defer wg.Done()
+			diffuseScentSlice(startY, endY)
+		}(startY, endY)
+	}
+	wg.Wait()
+}
+
+// diffuseScentSlice evaporates and diffuses the scent field for rows
+// [startY, endY), writing into nextScentField:
+//
+//	new[x][y] = (1 - evap) * (alpha*self + beta*avg(neighbors))
+func diffuseScentSlice(startY, endY int) {
+	alpha := float32(*scentAlpha)
+	beta := float32(*scentBeta)
+	evap := float32(1 - *scentEvap)
+
+	for y := startY; y < endY; y++ {
+		for x := 0; x < *wwidth; x++ {
+			n, s, e, w := adjacent(x, y)
+			avgNeighbor := (scentField[n.x][n.y] + scentField[s.x][s.y] + scentField[e.x][e.y] + scentField[w.x][w.y]) / 4
+			nextScentField[x][y] = evap * (alpha*scentField[x][y] + beta*avgNeighbor)
+		}
+	}
+}
+
+// bestScentNeighbor returns the neighbor coordinate of (x, y) with the
+// highest scent value among the four adjacent cells, used by a hunting
+// shark with no adjacent fish. Ties are broken randomly via r so a plateau
+// doesn't collapse to a single preferred direction.
+func bestScentNeighbor(x, y int, r *rand.Rand) coordinate {
+	n, s, e, w := adjacent(x, y)
+	candidates := [4]coordinate{n, s, e, w}
+
+	var tied []coordinate
+	best := float32(-1)
+	for _, c := range candidates {
+		v := scentField[c.x][c.y]
+		switch {
+		case v > best:
+			best = v
+			tied = tied[:0]
+			tied = append(tied, c)
+		case v == best:
+			tied = append(tied, c)
+		}
+	}
+	return tied[r.IntN(len(tied))]
+}
@@ -0,0 +1,57 @@
+// This file adds the -schedule=agentlist scheduler: instead of dispatching
+// rows top-to-bottom (which systematically gives low-x/low-y creatures first
+// pick of contested cells), it builds a shuffled flat list of every live
+// coordinate and splits that into *nThreads chunks, so which goroutine
+// handles a creature no longer depends on its grid position.
+package main
+
+import "flag"
+
+// scheduleMode selects how Chronon dispatches creatures to worker
+// goroutines: "rowstrip" (default, top-to-bottom horizontal strips) or
+// "agentlist" (shuffled whole-grid coordinate list, chunked evenly).
+var scheduleMode = flag.String("schedule", "rowstrip", "Agent scheduling strategy: rowstrip|agentlist.")
+
+// liveAgents scans the grid and returns the coordinates of every live
+// creature, in row-major order (shuffled by the caller before use).
+func liveAgents() []coordinate {
+	var agents []coordinate
+	for x := 0; x < *wwidth; x++ {
+		for y := 0; y < *wheight; y++ {
+			if world[x][y] != nil {
+				agents = append(agents, coordinate{x, y})
+			}
+		}
+	}
+	return agents
+}
+
+// chunkAgents splits agents into n roughly-equal contiguous slices (the
+// first len(agents)%n chunks get one extra element), for one chunk per
+// worker goroutine. Callers should shuffle agents first; chunkAgents itself
+// does no reordering.
+func chunkAgents(agents []coordinate, n int) [][]coordinate {
+	if n < 1 {
+		n = 1
+	}
+	if n > len(agents) {
+		n = len(agents)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	chunks := make([][]coordinate, n)
+	base := len(agents) / n
+	rem := len(agents) % n
+	idx := 0
+	for i := 0; i < n; i++ {
+		size := base
+		if i < rem {
+			size++
+		}
+		chunks[i] = agents[idx : idx+size]
+		idx += size
+	}
+	return chunks
+}
@@ -0,0 +1,104 @@
+package main
+
+import "testing"
+
+// TestLiveAgentsFindsEveryOccupiedCell asserts liveAgents returns exactly
+// the occupied coordinates, since agentlist scheduling depends on the
+// chunked list covering the whole live population.
+func TestLiveAgentsFindsEveryOccupiedCell(t *testing.T) {
+	*wwidth, *wheight = 3, 3
+	world = make([][]*creature, *wwidth)
+	for x := range world {
+		world[x] = make([]*creature, *wheight)
+	}
+	world[0][0] = &creature{species: FISH}
+	world[2][1] = &creature{species: SHARK}
+
+	agents := liveAgents()
+	if len(agents) != 2 {
+		t.Fatalf("len(agents) = %d, want 2", len(agents))
+	}
+	want := map[coordinate]bool{{x: 0, y: 0}: true, {x: 2, y: 1}: true}
+	for _, a := range agents {
+		if !want[a] {
+			t.Fatalf("unexpected coordinate %v in liveAgents result", a)
+		}
+		delete(want, a)
+	}
+	if len(want) != 0 {
+		t.Fatalf("liveAgents missed coordinates: %v", want)
+	}
+}
+
+// TestChunkAgentsCoversEveryAgentExactlyOnce asserts chunkAgents' slices
+// partition the input with no gaps, overlaps, or reordering, which is what
+// lets each worker goroutine safely own one chunk with no further
+// synchronization.
+func TestChunkAgentsCoversEveryAgentExactlyOnce(t *testing.T) {
+	agents := make([]coordinate, 10)
+	for i := range agents {
+		agents[i] = coordinate{x: i, y: 0}
+	}
+
+	chunks := chunkAgents(agents, 3)
+	if len(chunks) != 3 {
+		t.Fatalf("len(chunks) = %d, want 3", len(chunks))
+	}
+
+	var reassembled []coordinate
+	for _, c := range chunks {
+		reassembled = append(reassembled, c...)
+	}
+	if len(reassembled) != len(agents) {
+		t.Fatalf("reassembled length = %d, want %d", len(reassembled), len(agents))
+	}
+	for i := range agents {
+		if reassembled[i] != agents[i] {
+			t.Fatalf("reassembled[%d] = %v, want %v (chunkAgents must not reorder)", i, reassembled[i], agents[i])
+		}
+	}
+}
+
+// TestChunkAgentsDistributesRemainderToLeadingChunks asserts the first
+// len(agents)%n chunks get the extra element, so chunk sizes never differ
+// by more than one.
+func TestChunkAgentsDistributesRemainderToLeadingChunks(t *testing.T) {
+	agents := make([]coordinate, 10)
+	chunks := chunkAgents(agents, 3)
+
+	sizes := make([]int, len(chunks))
+	for i, c := range chunks {
+		sizes[i] = len(c)
+	}
+	want := []int{4, 3, 3}
+	for i := range want {
+		if sizes[i] != want[i] {
+			t.Fatalf("chunk sizes = %v, want %v", sizes, want)
+		}
+	}
+}
+
+// TestChunkAgentsNeverExceedsAgentCount asserts n is clamped down to
+// len(agents), so a small live population with -threads set high doesn't
+// produce empty chunks.
+func TestChunkAgentsNeverExceedsAgentCount(t *testing.T) {
+	agents := make([]coordinate, 2)
+	chunks := chunkAgents(agents, 8)
+	if len(chunks) != 2 {
+		t.Fatalf("len(chunks) = %d, want 2", len(chunks))
+	}
+	for _, c := range chunks {
+		if len(c) != 1 {
+			t.Fatalf("chunk size = %d, want 1", len(c))
+		}
+	}
+}
+
+// TestChunkAgentsEmptyInput asserts an empty agent list produces no chunks,
+// rather than a slice of empty chunks a caller would otherwise have to
+// special-case.
+func TestChunkAgentsEmptyInput(t *testing.T) {
+	if chunks := chunkAgents(nil, 4); chunks != nil {
+		t.Fatalf("chunkAgents(nil, 4) = %v, want nil", chunks)
+	}
+}
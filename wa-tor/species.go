@@ -0,0 +1,268 @@
+// This file replaces the hard-coded FISH/SHARK switch in stepCreature with a
+// pluggable Species registry. A Species owns the rules for how one kind of
+// creature moves, hunts, and reproduces; FISH and SHARK are just the two
+// default registrations (see registerDefaultSpecies below), and a third
+// trophic level — an "orca" that eats sharks, "plankton" that fish eat, and
+// so on — can be added at startup from a JSON file (see speciesconfig.go)
+// without touching stepCreature or this file at all.
+package main
+
+import (
+	"image/color"
+	"math/rand/v2"
+	"sync/atomic"
+	"unsafe"
+)
+
+// StepCtx carries everything a Species needs to evaluate one creature during
+// a single chronon: its coordinate, the chronon index, the double-buffered
+// grids, and a thread-local RNG owned by the calling goroutine. Exposing
+// World/NextWorld/Rand and the CAS helper here (rather than leaving them as
+// main.go package globals) is what lets a Species be written, and even
+// loaded from outside this package, without depending on anything stepCreature does.
+type StepCtx struct {
+	X, Y    int
+	Chronon int
+
+	World, NextWorld [][]*creature
+	Rand             *rand.Rand
+
+	// Stats, if non-nil, receives birth/death events for this chronon (see
+	// statsseries.go). It is owned by the calling goroutine alone, so Step
+	// implementations can increment its fields directly without locking.
+	Stats *chrononStats
+}
+
+// CAS attempts to atomically claim ctx.NextWorld[x][y] for cr, returning
+// true on success.
+func (ctx *StepCtx) CAS(x, y int, cr *creature) bool {
+	return atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(&ctx.NextWorld[x][y])), nil, unsafe.Pointer(cr))
+}
+
+// Species implements the full behavior of one kind of creature for a single
+// chronon: movement, hunting, starvation, and reproduction all happen inside
+// Step, which is responsible for placing (or leaving) the creature in
+// ctx.NextWorld via ctx.CAS.
+type Species interface {
+	// Step advances the creature at ctx.X, ctx.Y by one chronon.
+	Step(ctx *StepCtx)
+
+	// Color returns the asset used to render this species.
+	Color() color.RGBA
+
+	// Name returns the species' identifier, used by -species-config and log output.
+	Name() string
+}
+
+// speciesRegistry maps a species id to its behavior. It is populated once at
+// startup (see registerDefaultSpecies and loadSpeciesConfig) and read
+// concurrently by every stepCreature call thereafter, so it must not be
+// mutated once the simulation is running.
+var speciesRegistry = map[int]Species{}
+
+// RegisterSpecies installs s as the behavior for species id, overwriting any
+// previous registration for that id. A -species-config file is loaded after
+// the classical defaults, so it can both add new trophic levels and
+// reconfigure FISH/SHARK.
+func RegisterSpecies(id int, s Species) {
+	speciesRegistry[id] = s
+}
+
+// speciesFor looks up the registered behavior for a creature's species id.
+func speciesFor(id int) Species {
+	return speciesRegistry[id]
+}
+
+// registerDefaultSpecies installs the classical FISH and SHARK rules as
+// genericSpecies values. It must run after flag.Parse, since it reads
+// *fBreed/*sBreed/*starve.
+func registerDefaultSpecies() {
+	RegisterSpecies(FISH, &genericSpecies{
+		id:    FISH,
+		name:  "fish",
+		color: fishcolor,
+		breed: *fBreed,
+	})
+	RegisterSpecies(SHARK, &genericSpecies{
+		id:        SHARK,
+		name:      "shark",
+		color:     sharkcolor,
+		breed:     *sBreed,
+		hasHealth: true,
+		starve:    *starve,
+		preyIDs:   []int{FISH},
+	})
+}
+
+// genericSpecies implements Species data-drivenly from a handful of
+// parameters, so both the built-in FISH/SHARK and any -species-config
+// addition share one code path:
+//
+//   - a creature with no preyIDs is a herbivore/autotroph: it wanders to a
+//     random empty neighbor and breeds every `breed` chronons, exactly like
+//     the classical fish.
+//   - a creature with preyIDs is a predator: it hunts an adjacent prey
+//     creature first, falls back to the scent gradient (see scent.go) if
+//     enabled and no prey was adjacent, and finally wanders at random.
+//   - hasHealth gates the starve/feed mechanic; herbivores ignore health.
+type genericSpecies struct {
+	id      int
+	name    string
+	color   color.RGBA
+	breed   int
+	preyIDs []int
+
+	hasHealth bool
+	starve    int // health a successful hunt restores; only meaningful if hasHealth.
+}
+
+func (s *genericSpecies) Name() string      { return s.name }
+func (s *genericSpecies) Color() color.RGBA { return s.color }
+func (s *genericSpecies) isPredator() bool  { return len(s.preyIDs) > 0 }
+func (s *genericSpecies) eats(species int) bool {
+	for _, id := range s.preyIDs {
+		if id == species {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *genericSpecies) Step(ctx *StepCtx) {
+	cr := *ctx.World[ctx.X][ctx.Y]
+	cr.age++
+	cr.chronon = ctx.Chronon
+
+	// Scent is specifically "fish scent" (see scent.go); only FISH deposits it.
+	if s.id == FISH {
+		depositScent(ctx.X, ctx.Y)
+	}
+
+	if s.hasHealth {
+		cr.health--
+		if cr.health <= 0 {
+			recordSpeciesDeath(s.id, cr.age)
+			if ctx.Stats != nil {
+				ctx.Stats.StarveDeaths++
+			}
+			return
+		}
+	}
+
+	if s.isPredator() && s.hunt(ctx, &cr) {
+		return
+	}
+
+	if s.isPredator() && scentField != nil && s.followScent(ctx, &cr) {
+		return
+	}
+
+	if s.wander(ctx, &cr) {
+		return
+	}
+
+	ctx.CAS(ctx.X, ctx.Y, &cr)
+}
+
+// hunt tries each of the four neighbors in a shuffled order and, on finding
+// one occupied by a registered prey species, attempts to claim its cell.
+func (s *genericSpecies) hunt(ctx *StepCtx, cr *creature) bool {
+	for i := 0; i < 4; i++ {
+		nx, ny := pickDirection(ctx, i)
+		prey := ctx.World[nx][ny]
+		if prey == nil || !s.eats(prey.species) {
+			continue
+		}
+		if s.hasHealth {
+			cr.health = s.starve
+		}
+		preySpecies, preyAge := prey.species, prey.age
+		if !ctx.CAS(nx, ny, cr) {
+			continue
+		}
+		recordSpeciesDeath(preySpecies, preyAge)
+		if ctx.Stats != nil {
+			ctx.Stats.PredationDeaths++
+		}
+		s.placeBaby(ctx, cr)
+		return true
+	}
+	return false
+}
+
+// followScent moves to the strongest-smelling neighbor, used by a predator
+// that found no adjacent prey. If that neighbor is occupied, or its cell is
+// claimed first by another creature, it does not move and returns false.
+func (s *genericSpecies) followScent(ctx *StepCtx, cr *creature) bool {
+	best := bestScentNeighbor(ctx.X, ctx.Y, ctx.Rand)
+	if ctx.World[best.x][best.y] != nil {
+		return false
+	}
+	if !ctx.CAS(best.x, best.y, cr) {
+		return false
+	}
+	s.placeBaby(ctx, cr)
+	return true
+}
+
+// wander moves to a uniformly random empty neighbor.
+func (s *genericSpecies) wander(ctx *StepCtx, cr *creature) bool {
+	for i := 0; i < 4; i++ {
+		nx, ny := pickDirection(ctx, i)
+		if ctx.World[nx][ny] != nil {
+			continue
+		}
+		if !ctx.CAS(nx, ny, cr) {
+			continue
+		}
+		s.placeBaby(ctx, cr)
+		return true
+	}
+	return false
+}
+
+// placeBaby reproduces cr, if it's old enough, into cr's now-vacated
+// original cell and records the birth for -stats-out.
+func (s *genericSpecies) placeBaby(ctx *StepCtx, cr *creature) {
+	baby := s.reproduce(ctx, cr)
+	if baby == nil {
+		return
+	}
+	if ctx.CAS(ctx.X, ctx.Y, baby) && ctx.Stats != nil {
+		ctx.Stats.Births++
+	}
+}
+
+// reproduce breeds cr if it has reached the `breed` age, splitting its
+// health with the child when hasHealth is set (mirroring the classical
+// shark's energy split).
+func (s *genericSpecies) reproduce(ctx *StepCtx, cr *creature) *creature {
+	if s.breed <= 0 || cr.age == 0 || cr.age%s.breed != 0 {
+		return nil
+	}
+	health := 0
+	if s.hasHealth {
+		health = cr.health / 2
+		cr.health -= health
+	}
+	return &creature{age: 0, health: health, species: s.id, asset: s.color, chronon: cr.chronon}
+}
+
+// pickDirection returns the neighbor of (ctx.X, ctx.Y) in direction
+// (d+i)%4, where d is a fresh random offset drawn on every call, so callers
+// probing i = 0..3 don't favor NORTH. Successive calls draw independent
+// offsets, so such a probe can see one direction more than once.
+func pickDirection(ctx *StepCtx, i int) (int, int) {
+	north, south, east, west := adjacent(ctx.X, ctx.Y)
+	d := ctx.Rand.IntN(4)
+	switch (d + i) % 4 {
+	case NORTH:
+		return north.x, north.y
+	case SOUTH:
+		return south.x, south.y
+	case EAST:
+		return east.x, east.y
+	default:
+		return west.x, west.y
+	}
+}
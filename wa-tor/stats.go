@@ -0,0 +1,52 @@
+// This file tracks mean creature lifetime so -benchmark output can quantify
+// the movement-order bias -schedule exists to fix: running the same config
+// once under rowstrip and once under agentlist and comparing the reported
+// means shows how much longer creatures survive once low-coordinate
+// creatures stop getting first pick of contested cells.
+//
+// Lifetime is tracked per species id rather than just FISH/SHARK so a
+// species registered from -species-config (see speciesconfig.go) gets the
+// same statistic for free.
+package main
+
+import "sync"
+
+var (
+	lifetimeMu     sync.Mutex
+	lifetimeSum    = map[int]int64{}
+	lifetimeDeaths = map[int]int64{}
+)
+
+// recordSpeciesDeath is called at the one unambiguous death event for a
+// species: a predator's hunting CAS successfully claiming its cell, or (for
+// a species with hasHealth) its health reaching zero before it could feed.
+func recordSpeciesDeath(species, age int) {
+	lifetimeMu.Lock()
+	lifetimeSum[species] += int64(age)
+	lifetimeDeaths[species]++
+	lifetimeMu.Unlock()
+}
+
+// meanLifetime returns the mean age, in chronons, at which creatures of
+// species have died so far, or 0 if none have died yet.
+func meanLifetime(species int) float64 {
+	lifetimeMu.Lock()
+	defer lifetimeMu.Unlock()
+	d := lifetimeDeaths[species]
+	if d == 0 {
+		return 0
+	}
+	return float64(lifetimeSum[species]) / float64(d)
+}
+
+// meanFishLifetime returns the mean age, in chronons, of fish at the point
+// they were eaten, or 0 if none have died yet.
+func meanFishLifetime() float64 {
+	return meanLifetime(FISH)
+}
+
+// meanSharkLifetime returns the mean age, in chronons, of sharks at the
+// point they starved, or 0 if none have died yet.
+func meanSharkLifetime() float64 {
+	return meanLifetime(SHARK)
+}
@@ -0,0 +1,106 @@
+// This file turns -benchmark from a pure timer into a time-series collector:
+// each Chronon worker tallies the births and deaths it caused into a small
+// chrononStats struct and sends it back through a channel, and Chronon adds
+// those to a full-grid fish/shark count (tallyPopulation, run after every
+// worker has finished) to make one row per chronon. The
+// series is optionally written to CSV (-stats-out) and is what
+// fitLotkaVolterra and estimatePeriod (lotkavolterra.go) analyze once the run
+// ends.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// statsOutPath is where the per-chronon CSV is written, if set. Only
+// meaningful in -benchmark mode.
+var statsOutPath = flag.String("stats-out", "", "Write per-chronon population/birth/death CSV to this path (benchmark mode only).")
+
+// chrononStats is a per-chronon tally. Workers fill in Births and the death
+// counts; tallyPopulation fills in Fish, Shark and SharkHealthSum. Chronon
+// sums all of these into the aggregate row for that chronon.
+type chrononStats struct {
+	Fish, Shark     int
+	Births          int
+	StarveDeaths    int
+	PredationDeaths int
+	SharkHealthSum  int64
+}
+
+// add accumulates other into s.
+func (s *chrononStats) add(other chrononStats) {
+	s.Fish += other.Fish
+	s.Shark += other.Shark
+	s.Births += other.Births
+	s.StarveDeaths += other.StarveDeaths
+	s.PredationDeaths += other.PredationDeaths
+	s.SharkHealthSum += other.SharkHealthSum
+}
+
+// meanSharkHealth returns the mean health of sharks alive this chronon, or 0
+// if there are none.
+func (s chrononStats) meanSharkHealth() float64 {
+	if s.Shark == 0 {
+		return 0
+	}
+	return float64(s.SharkHealthSum) / float64(s.Shark)
+}
+
+// chrononSeries accumulates one aggregated chrononStats per chronon across
+// the whole benchmark run, for fitLotkaVolterra and estimatePeriod to
+// analyze afterward. Only appended to in benchmark mode.
+var chrononSeries []chrononStats
+
+var statsCSV *csv.Writer
+var statsFile *os.File
+
+// openStatsCSV opens -stats-out (if set) and writes its header. It must be
+// paired with closeStatsCSV once the benchmark run ends.
+func openStatsCSV() {
+	if *statsOutPath == "" {
+		return
+	}
+	f, err := os.Create(*statsOutPath)
+	if err != nil {
+		log.Fatalf("stats-out: %v", err)
+	}
+	statsFile = f
+	statsCSV = csv.NewWriter(f)
+	if err := statsCSV.Write([]string{"chronon", "fish", "shark", "births", "starve_deaths", "predation_deaths", "mean_shark_health"}); err != nil {
+		log.Fatalf("stats-out: %v", err)
+	}
+}
+
+// recordChrononStats appends s to chrononSeries and, if -stats-out is set,
+// writes it as the next CSV row.
+func recordChrononStats(c int, s chrononStats) {
+	chrononSeries = append(chrononSeries, s)
+	if statsCSV == nil {
+		return
+	}
+	row := []string{
+		fmt.Sprintf("%d", c),
+		fmt.Sprintf("%d", s.Fish),
+		fmt.Sprintf("%d", s.Shark),
+		fmt.Sprintf("%d", s.Births),
+		fmt.Sprintf("%d", s.StarveDeaths),
+		fmt.Sprintf("%d", s.PredationDeaths),
+		fmt.Sprintf("%.4f", s.meanSharkHealth()),
+	}
+	if err := statsCSV.Write(row); err != nil {
+		log.Fatalf("stats-out: %v", err)
+	}
+}
+
+// closeStatsCSV flushes and closes the -stats-out file, if one was opened.
+func closeStatsCSV() {
+	if statsCSV == nil {
+		return
+	}
+	statsCSV.Flush()
+	statsFile.Close()
+}